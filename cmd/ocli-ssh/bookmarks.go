@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// toggleBookmark stars or unstars the selected bullet. Stars are kept by ID,
+// so they follow the bullet wherever it is moved.
+func (m *Model) toggleBookmark() {
+	selected := m.getSelectedBullet()
+	if selected == nil {
+		return
+	}
+	m.recordUndo()
+
+	if i := indexOfID(m.bookmarks, selected.ID); i >= 0 {
+		m.bookmarks = append(m.bookmarks[:i:i], m.bookmarks[i+1:]...)
+		m.statusMessage = "Unstarred"
+	} else {
+		m.bookmarks = append(m.bookmarks, selected.ID)
+		m.statusMessage = "Starred (b to list bookmarks)"
+	}
+
+	// Auto-save after starring
+	m.saveData()
+}
+
+func indexOfID(ids []string, id string) int {
+	for i, candidate := range ids {
+		if candidate == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// isBookmarked reports whether b is starred.
+func (m Model) isBookmarked(b *Bullet) bool {
+	return indexOfID(m.bookmarks, b.ID) >= 0
+}
+
+// bookmarkedBullets returns the starred bullets that are in the outline, in
+// the order they were starred. Stars on deleted bullets are kept so they come
+// back with the bullet when it is restored from the trash.
+func (m Model) bookmarkedBullets() []*Bullet {
+	var bullets []*Bullet
+	for _, id := range m.bookmarks {
+		if b := findBulletByID(m.rootBullets, id); b != nil {
+			bullets = append(bullets, b)
+		}
+	}
+	return bullets
+}
+
+// updateBookmarks handles keys in the bookmarks panel.
+func (m Model) updateBookmarks(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	bullets := m.bookmarkedBullets()
+	key := msg.String()
+	switch key {
+	case "q", "esc", "b":
+		m.appMode = AppModeNormal
+		m.ensureSelectedVisible()
+
+	case "up", "k":
+		if m.bookmarkIndex > 0 {
+			m.bookmarkIndex--
+		}
+
+	case "down", "j":
+		if m.bookmarkIndex < len(bullets)-1 {
+			m.bookmarkIndex++
+		}
+
+	case "enter", "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		index := m.bookmarkIndex
+		if key != "enter" {
+			index = int(key[0] - '1')
+		}
+		if index < len(bullets) {
+			m.appMode = AppModeNormal
+			m.jumpTo(bullets[index])
+		}
+
+	case "d", "*":
+		if m.bookmarkIndex >= len(bullets) {
+			return m, nil
+		}
+		if m.readOnly {
+			m.statusMessage = "Read-only: changes are disabled"
+			return m, nil
+		}
+		m.recordUndo()
+		i := indexOfID(m.bookmarks, bullets[m.bookmarkIndex].ID)
+		m.bookmarks = append(m.bookmarks[:i:i], m.bookmarks[i+1:]...)
+		if m.bookmarkIndex >= len(bullets)-1 && m.bookmarkIndex > 0 {
+			m.bookmarkIndex--
+		}
+		// Auto-save after unstarring
+		m.saveData()
+	}
+	return m, nil
+}
+
+func (m Model) renderBookmarks(appStyle, titleStyle lipgloss.Style) string {
+	var contentBuilder strings.Builder
+
+	contentBuilder.WriteString(titleStyle.Render("Bookmarks"))
+	contentBuilder.WriteString("\n\n")
+
+	itemStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("255")).
+		Underline(true)
+	detailStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	bullets := m.bookmarkedBullets()
+	if len(bullets) == 0 {
+		contentBuilder.WriteString(detailStyle.Render("No bookmarks yet. Press * on a bullet to star it."))
+		contentBuilder.WriteString("\n")
+	}
+
+	// Two lines per entry; keep the selected entry in view
+	rows := (m.height - 8) / 2
+	if rows < 1 {
+		rows = 1
+	}
+	start := 0
+	if m.bookmarkIndex >= rows {
+		start = m.bookmarkIndex - rows + 1
+	}
+	width := m.width - 8
+	for i := start; i < len(bullets) && i < start+rows; i++ {
+		b := bullets[i]
+		number := "  "
+		if i < 9 {
+			number = fmt.Sprintf("%d ", i+1)
+		}
+		line := b.Content
+		if width > 1 {
+			line = truncate(line, width)
+		}
+		contentBuilder.WriteString(detailStyle.Render(number))
+		if i == m.bookmarkIndex {
+			contentBuilder.WriteString(selectedStyle.Render(line))
+		} else {
+			contentBuilder.WriteString(itemStyle.Render(line))
+		}
+		contentBuilder.WriteString("\n")
+
+		path := "top level"
+		if b.Parent != nil {
+			path = b.Parent.PathString()
+		}
+		if width > 1 {
+			path = truncate(path, width)
+		}
+		contentBuilder.WriteString(detailStyle.Render("  " + path))
+		contentBuilder.WriteString("\n")
+	}
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		MarginTop(2)
+
+	help := "\nKeys: 1-9:jump • ↑↓/jk:navigate • Enter:jump • d:unstar • b/esc/q:back"
+	if m.statusMessage != "" {
+		help = "\n" + m.statusMessage
+	}
+	contentBuilder.WriteString(helpStyle.Render(help))
+
+	return appStyle.Render(contentBuilder.String())
+}
@@ -1,6 +1,10 @@
 package main
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/google/uuid"
 )
 
@@ -14,9 +18,22 @@ const (
 	ColorRed
 )
 
+// minIDPrefix is the shortest ID prefix shown to users in place of a full ID.
+const minIDPrefix = 8
+
+var colorNames = []string{"default", "blue", "green", "yellow", "red"}
+
+func (c BulletColor) String() string {
+	if c >= 0 && int(c) < len(colorNames) {
+		return colorNames[c]
+	}
+	return fmt.Sprintf("color(%d)", int(c))
+}
+
 type Bullet struct {
 	ID        string
 	Content   string
+	Note      string
 	Children  []*Bullet
 	Parent    *Bullet
 	Collapsed bool
@@ -132,3 +149,134 @@ func (b *Bullet) ToggleComplete() {
 		b.Completed = !b.Completed
 	}
 }
+
+// GetPath returns the chain of bullets from the root down to b (inclusive).
+func (b *Bullet) GetPath() []*Bullet {
+	var path []*Bullet
+	for current := b; current != nil; current = current.Parent {
+		path = append([]*Bullet{current}, path...)
+	}
+	return path
+}
+
+// PathString renders the bullet's location as "Parent > Child > Bullet".
+func (b *Bullet) PathString() string {
+	var parts []string
+	for _, p := range b.GetPath() {
+		parts = append(parts, p.Content)
+	}
+	return strings.Join(parts, " > ")
+}
+
+// walkBullets calls fn for every bullet in the given trees, depth first.
+func walkBullets(bullets []*Bullet, fn func(*Bullet)) {
+	for _, b := range bullets {
+		fn(b)
+		walkBullets(b.Children, fn)
+	}
+}
+
+// findBulletByID looks up a bullet by its exact ID.
+func findBulletByID(bullets []*Bullet, id string) *Bullet {
+	var found *Bullet
+	walkBullets(bullets, func(b *Bullet) {
+		if found == nil && b.ID == id {
+			found = b
+		}
+	})
+	return found
+}
+
+// resolveBulletID finds a bullet by exact ID or by an unambiguous ID prefix.
+func resolveBulletID(bullets []*Bullet, ref string) (*Bullet, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("empty bullet ID")
+	}
+	if b := findBulletByID(bullets, ref); b != nil {
+		return b, nil
+	}
+
+	var matches []*Bullet
+	walkBullets(bullets, func(b *Bullet) {
+		if strings.HasPrefix(b.ID, ref) {
+			matches = append(matches, b)
+		}
+	})
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no bullet with ID %q", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("ID prefix %q is ambiguous (%d matches)", ref, len(matches))
+	}
+}
+
+// copyTree deep-copies bullets, keeping their IDs. The copies carry no parent
+// references so they can be serialized; use linkParents to restore them.
+func copyTree(bullets []*Bullet) []*Bullet {
+	copies := make([]*Bullet, len(bullets))
+	for i, b := range bullets {
+		copies[i] = &Bullet{
+			ID:        b.ID,
+			Content:   b.Content,
+			Note:      b.Note,
+			Children:  copyTree(b.Children),
+			Collapsed: b.Collapsed,
+			Color:     b.Color,
+			IsTask:    b.IsTask,
+			Completed: b.Completed,
+		}
+	}
+	return copies
+}
+
+// renewIDs gives every bullet in the trees a fresh ID, for copies that live
+// alongside their originals.
+func renewIDs(bullets []*Bullet) {
+	walkBullets(bullets, func(b *Bullet) {
+		b.ID = uuid.New().String()
+	})
+}
+
+// linkParents points every bullet's Parent at the bullet containing it.
+func linkParents(bullets []*Bullet, parent *Bullet) {
+	for _, b := range bullets {
+		b.Parent = parent
+		linkParents(b.Children, b)
+	}
+}
+
+// shortIDPrefixes maps each ID to its shortest prefix that no other ID shares.
+func shortIDPrefixes(ids []string) map[string]string {
+	sorted := append([]string{}, ids...)
+	sort.Strings(sorted)
+
+	prefixes := make(map[string]string, len(ids))
+	for i, id := range sorted {
+		length := minIDPrefix
+		// Only the sorted neighbours can share a longer prefix
+		for _, j := range []int{i - 1, i + 1} {
+			if j < 0 || j >= len(sorted) || sorted[j] == id {
+				continue
+			}
+			if shared := commonPrefixLength(id, sorted[j]); shared+1 > length {
+				length = shared + 1
+			}
+		}
+		if length > len(id) {
+			length = len(id)
+		}
+		prefixes[id] = id[:length]
+	}
+	return prefixes
+}
+
+func commonPrefixLength(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,69 @@
+package main
+
+// Folding commands follow vim's z prefix. They work on the whole outline,
+// or on what is below the zoomed bullet when zoomed in.
+
+// foldHelp is shown in place of the help line while z waits for its command.
+const foldHelp = "z: M collapse all • R expand all • O expand branch • C collapse siblings • 1-9 show levels"
+
+// setCollapsed folds or unfolds every bullet with children in the trees.
+func setCollapsed(bullets []*Bullet, collapsed bool) {
+	walkBullets(bullets, func(b *Bullet) {
+		b.Collapsed = collapsed && len(b.Children) > 0
+	})
+}
+
+// foldToLevel shows n levels of bullets and collapses everything deeper.
+func foldToLevel(bullets []*Bullet, n int) {
+	var fold func(bullets []*Bullet, level int)
+	fold = func(bullets []*Bullet, level int) {
+		for _, b := range bullets {
+			b.Collapsed = level >= n && len(b.Children) > 0
+			fold(b.Children, level+1)
+		}
+	}
+	fold(bullets, 1)
+}
+
+// fold runs the folding command typed after z.
+func (m *Model) fold(key string) {
+	selected := m.getSelectedBullet()
+	scope := m.childrenOf(m.zoomedBullet)
+
+	switch key {
+	case "M":
+		setCollapsed(scope, true)
+	case "R":
+		setCollapsed(scope, false)
+	case "O":
+		if selected == nil {
+			return
+		}
+		selected.Collapsed = false
+		setCollapsed(selected.Children, false)
+	case "C":
+		if selected == nil || selected == m.zoomedBullet {
+			return
+		}
+		for _, sibling := range m.siblingsOf(selected) {
+			if sibling != selected {
+				sibling.Collapsed = len(sibling.Children) > 0
+			}
+		}
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		foldToLevel(scope, int(key[0]-'0'))
+	default:
+		return
+	}
+
+	m.rebuildVisibleList()
+	// Keep the selection on the selected bullet, or on the nearest ancestor
+	// that is still shown
+	for b := selected; b != nil; b = b.Parent {
+		if indexOf(m.allBullets, b) >= 0 {
+			m.selectBullet(b)
+			return
+		}
+	}
+	m.clampSelection()
+}
@@ -48,6 +48,11 @@ func main() {
 		envAutoRegister, _ = strconv.ParseBool(ar)
 	}
 
+	envReadOnly := false
+	if ro := os.Getenv("OCLI_SSH_READONLY"); ro != "" {
+		envReadOnly, _ = strconv.ParseBool(ro)
+	}
+
 	var (
 		host         = flag.String("host", envHost, "Host to bind SSH server to")
 		port         = flag.String("port", envPort, "Port to bind SSH server to")
@@ -56,6 +61,7 @@ func main() {
 		addUser      = flag.String("add-user", "", "Add a new user (format: username:path/to/public_key.pub)")
 		delUser      = flag.String("del-user", "", "Remove a user")
 		autoRegister = flag.Bool("auto-register", envAutoRegister, "Automatically register new users on first connection")
+		readOnly     = flag.Bool("readonly", envReadOnly, "Serve every outline read-only")
 	)
 	flag.Parse()
 
@@ -80,7 +86,7 @@ func main() {
 	}
 
 	// Initialize server
-	srv, err := NewServer(*host, *port, *dataDir, *keyPath, *autoRegister)
+	srv, err := NewServer(*host, *port, *dataDir, *keyPath, *autoRegister, *readOnly)
 	if err != nil {
 		log.Fatal("Failed to create server:", err)
 	}
@@ -97,6 +103,9 @@ func main() {
 	} else {
 		log.Println("Auto-registration: DISABLED")
 	}
+	if *readOnly {
+		log.Println("Read-only: ENABLED (outlines can be viewed but not changed)")
+	}
 	log.Println("")
 	if !*autoRegister {
 		log.Println("To add users: ocli-ssh --add-user username:path/to/key.pub")
@@ -3,7 +3,11 @@ package main
 import (
 	"fmt"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -16,6 +20,9 @@ const (
 	EditModeNone EditMode = iota
 	EditModeNew
 	EditModeEdit
+	EditModeNote
+	EditModeSearch
+	EditModeFilter
 )
 
 type AppMode int
@@ -24,10 +31,16 @@ const (
 	AppModeNormal AppMode = iota
 	AppModeSettings
 	AppModeHelp
+	AppModeStats
+	AppModeTrash
+	AppModePicker
+	AppModeBookmarks
 )
 
 type Settings struct {
 	ShowHierarchyLines bool
+	ShowAllNotes       bool
+	TrashRetentionDays int // Deleted bullets older than this are purged; 0 keeps them
 }
 
 type Model struct {
@@ -37,6 +50,7 @@ type Model struct {
 	editMode        EditMode
 	appMode         AppMode
 	textInput       textinput.Model
+	noteInput       textarea.Model
 	editingBullet   *Bullet
 	width           int
 	height          int
@@ -46,6 +60,30 @@ type Model struct {
 	breadcrumbs     []*Bullet
 	configManager   *ConfigManager
 	scrollOffset    int
+	readOnly        bool
+	statusMessage   string
+	undoStack       []undoState
+	redoStack       []undoState
+	visualAnchor    *Bullet          // Other end of the range selection, nil outside visual mode
+	register        []*Bullet        // Yanked or cut subtrees
+	registerIsCut   bool             // The register holds a cut that has not been put yet
+	pending         *pendingInsert   // New bullet being typed inline
+	trash           []*RemovedBullet // Newest first
+	archive         []*RemovedBullet // Newest first
+	trashIndex      int
+	showArchive     bool // The trash view lists the archive instead
+	picker          *picker
+	search          *searchState // Active `/` search, kept for n/N until cleared
+	filter          *filterState // Narrows the view to matches and their ancestors
+	recent          []string     // IDs of recently visited bullets, newest first
+	bookmarks       []string     // IDs of starred bullets, in the order starred
+	bookmarkIndex   int
+	pendingKey      string // First key of a two-key command such as zM
+	count           int    // Count prefix typed so far, as in 5j
+	lastClick       time.Time
+	lastClickID     string // Bullet clicked last, to detect double-clicks
+	jumpBack        []location
+	jumpForward     []location
 }
 
 func NewModel() Model {
@@ -55,7 +93,7 @@ func NewModel() Model {
 	ti := textinput.New()
 	ti.Placeholder = "Enter text..."
 	ti.Focus()
-	ti.CharLimit = 256
+	ti.CharLimit = 0 // Bullets may be arbitrarily long; long content is wrapped
 
 	// Initialize config manager
 	configManager, err := NewConfigManager()
@@ -68,6 +106,7 @@ func NewModel() Model {
 		rootBullets:   make([]*Bullet, 0),
 		allBullets:    make([]*Bullet, 0),
 		textInput:     ti,
+		noteInput:     newNoteInput(),
 		editMode:      EditModeNone,
 		appMode:       AppModeNormal,
 		settingsIndex: 0,
@@ -77,10 +116,20 @@ func NewModel() Model {
 	}
 
 	// Load data from config or use defaults
+	var view *ViewState
 	if configManager != nil {
 		if data, err := configManager.Load(); err == nil {
 			m.rootBullets = data.RootBullets
 			m.settings = data.Settings
+			m.readOnly = data.ReadOnly
+			m.trash = data.Trash
+			m.archive = data.Archive
+			m.bookmarks = data.Bookmarks
+			m.purgeTrash(time.Now())
+			if history, err := configManager.LoadHistory(); err == nil {
+				m.restoreHistory(history)
+			}
+			view = data.View
 		} else {
 			// Use defaults if loading fails
 			m.loadDefaults()
@@ -91,6 +140,7 @@ func NewModel() Model {
 	}
 
 	m.rebuildVisibleList()
+	m.restoreView(view)
 	m.ensureSelectedVisible()
 	return m
 }
@@ -98,6 +148,7 @@ func NewModel() Model {
 func (m *Model) loadDefaults() {
 	m.settings = Settings{
 		ShowHierarchyLines: true,
+		TrashRetentionDays: defaultTrashRetentionDays,
 	}
 
 	// Use the same comprehensive tutorial as persistence layer
@@ -119,13 +170,28 @@ func (m *Model) saveData() error {
 	if m.configManager == nil {
 		return nil // No config manager, skip saving
 	}
+	if m.readOnly {
+		return nil // Never write an outline opened read-only
+	}
 
 	data := &AppData{
 		RootBullets: m.rootBullets,
 		Settings:    m.settings,
+		Trash:       m.trash,
+		Archive:     m.archive,
+		Bookmarks:   m.bookmarks,
+		View:        m.viewState(),
+	}
+
+	if err := m.configManager.Save(data); err != nil {
+		return err
 	}
+	return m.configManager.SaveHistory(m.history())
+}
 
-	return m.configManager.Save(data)
+// contentHeight is the number of lines available for bullets.
+func (m *Model) contentHeight() int {
+	return m.height - 6 // Title (2 lines) + breadcrumbs (2 lines) + help (2 lines)
 }
 
 func (m *Model) ensureSelectedVisible() {
@@ -133,17 +199,27 @@ func (m *Model) ensureSelectedVisible() {
 		return
 	}
 	
-	// Calculate available space for content (accounting for title, breadcrumbs, help text, etc.)
-	availableHeight := m.height - 6 // Title (2 lines) + breadcrumbs (2 lines) + help (2 lines)
-	if m.editMode == EditModeNew {
-		availableHeight -= 2 // New bullet input
-	}
+	availableHeight := m.contentHeight()
 	
-	// Ensure selected item is visible in viewport
+	// Ensure selected item (including any lines below it) is visible in viewport
 	if m.selectedIndex < m.scrollOffset {
 		m.scrollOffset = m.selectedIndex
-	} else if m.selectedIndex >= m.scrollOffset+availableHeight {
-		m.scrollOffset = m.selectedIndex - availableHeight + 1
+	}
+	lines := 0
+	for top := m.selectedIndex; top >= m.scrollOffset && top < len(m.allBullets); top-- {
+		lines += len(m.renderBullet(top))
+		if lines > availableHeight {
+			// Scroll down just far enough for the selection to fit at the bottom
+			m.scrollOffset = top + 1
+			if m.scrollOffset > m.selectedIndex {
+				m.scrollOffset = m.selectedIndex
+			}
+			break
+		}
+	}
+	// Leave room for the ancestors pinned above the list
+	for m.scrollOffset < m.selectedIndex && !m.fitsOnScreen(m.scrollOffset, m.selectedIndex) {
+		m.scrollOffset++
 	}
 	
 	// Ensure scroll offset doesn't go negative
@@ -152,9 +228,15 @@ func (m *Model) ensureSelectedVisible() {
 	}
 	
 	// Ensure we don't scroll past the content
-	maxScroll := len(m.allBullets) - availableHeight
-	if maxScroll < 0 {
-		maxScroll = 0
+	maxScroll := len(m.allBullets)
+	for lines := 0; maxScroll > 0; maxScroll-- {
+		lines += len(m.renderBullet(maxScroll - 1))
+		if lines > availableHeight {
+			break
+		}
+	}
+	for maxScroll < len(m.allBullets)-1 && !m.fitsOnScreen(maxScroll, len(m.allBullets)-1) {
+		maxScroll++
 	}
 	if m.scrollOffset > maxScroll {
 		m.scrollOffset = maxScroll
@@ -164,7 +246,9 @@ func (m *Model) ensureSelectedVisible() {
 func (m *Model) rebuildVisibleList() {
 	m.allBullets = make([]*Bullet, 0)
 	
-	if m.zoomedBullet != nil {
+	if m.filterActive() {
+		m.allBullets = m.filteredBullets()
+	} else if m.zoomedBullet != nil {
 		// When zoomed, only show the zoomed bullet and its children
 		m.allBullets = append(m.allBullets, m.zoomedBullet)
 		m.allBullets = append(m.allBullets, m.zoomedBullet.GetVisibleDescendants()...)
@@ -175,6 +259,7 @@ func (m *Model) rebuildVisibleList() {
 			m.allBullets = append(m.allBullets, root.GetVisibleDescendants()...)
 		}
 	}
+	m.showPending()
 }
 
 func (m *Model) getSelectedBullet() *Bullet {
@@ -184,71 +269,15 @@ func (m *Model) getSelectedBullet() *Bullet {
 	return nil
 }
 
-func (m *Model) addNewBullet(content string) {
-	newBullet := NewBullet(content)
-	selected := m.getSelectedBullet()
-
-	// When zoomed in, new bullets should be children of the zoomed bullet
-	if m.zoomedBullet != nil {
-		if selected == nil || selected == m.zoomedBullet {
-			// If no selection or selected is the zoomed bullet, add as child
-			m.zoomedBullet.AddChild(newBullet)
-		} else {
-			// Add as sibling to selected bullet (child of same parent)
-			parent := selected.Parent
-			if parent == nil {
-				// This shouldn't happen when zoomed, but fallback
-				m.zoomedBullet.AddChild(newBullet)
-			} else {
-				index := 0
-				for i, b := range parent.Children {
-					if b.ID == selected.ID {
-						index = i + 1
-						break
-					}
-				}
-				parent.InsertChildAt(index, newBullet)
-			}
-		}
-	} else {
-		// Normal (non-zoomed) behavior
-		if selected == nil {
-			m.rootBullets = append(m.rootBullets, newBullet)
-		} else {
-			if selected.Parent == nil {
-				index := 0
-				for i, b := range m.rootBullets {
-					if b.ID == selected.ID {
-						index = i + 1
-						break
-					}
-				}
-				m.rootBullets = append(m.rootBullets[:index], append([]*Bullet{newBullet}, m.rootBullets[index:]...)...)
-			} else {
-				parent := selected.Parent
-				index := 0
-				for i, b := range parent.Children {
-					if b.ID == selected.ID {
-						index = i + 1
-						break
-					}
-				}
-				parent.InsertChildAt(index, newBullet)
-			}
-		}
-	}
-
-	m.rebuildVisibleList()
-	for i, b := range m.allBullets {
-		if b.ID == newBullet.ID {
+// selectBullet moves the selection onto b if it is part of the visible list.
+func (m *Model) selectBullet(b *Bullet) {
+	for i, visible := range m.allBullets {
+		if visible == b {
 			m.selectedIndex = i
 			break
 		}
 	}
 	m.ensureSelectedVisible()
-	
-	// Auto-save after adding new bullet
-	m.saveData()
 }
 
 func (m *Model) deleteBullet() {
@@ -256,17 +285,14 @@ func (m *Model) deleteBullet() {
 	if selected == nil {
 		return
 	}
-
-	if selected.Parent == nil {
-		for i, b := range m.rootBullets {
-			if b.ID == selected.ID {
-				m.rootBullets = append(m.rootBullets[:i], m.rootBullets[i+1:]...)
-				break
-			}
-		}
-	} else {
-		selected.Parent.RemoveChild(selected)
+	if selected == m.zoomedBullet {
+		m.statusMessage = "Cannot delete the zoomed bullet"
+		return
 	}
+	m.recordUndo()
+
+	m.removeBullets([]*Bullet{selected}, &m.trash)
+	m.statusMessage = "Moved to trash (T to view)"
 
 	m.rebuildVisibleList()
 	if m.selectedIndex >= len(m.allBullets) && m.selectedIndex > 0 {
@@ -284,31 +310,24 @@ func (m *Model) indentBullet() {
 		return
 	}
 
-	var prevSibling *Bullet
+	// The first child has nowhere to go, so leave the undo history alone
+	siblings := m.siblingsOf(selected)
+	index := indexOf(siblings, selected)
+	if index < 1 {
+		return
+	}
+	prevSibling := siblings[index-1]
+	m.recordUndo()
+
 	if selected.Parent == nil {
-		for i, b := range m.rootBullets {
-			if b.ID == selected.ID && i > 0 {
-				prevSibling = m.rootBullets[i-1]
-				m.rootBullets = append(m.rootBullets[:i], m.rootBullets[i+1:]...)
-				break
-			}
-		}
+		m.rootBullets = append(m.rootBullets[:index], m.rootBullets[index+1:]...)
 	} else {
-		parent := selected.Parent
-		for i, b := range parent.Children {
-			if b.ID == selected.ID && i > 0 {
-				prevSibling = parent.Children[i-1]
-				parent.RemoveChild(selected)
-				break
-			}
-		}
+		selected.Parent.RemoveChild(selected)
 	}
 
-	if prevSibling != nil {
-		prevSibling.AddChild(selected)
-		if prevSibling.Collapsed {
-			prevSibling.Collapsed = false
-		}
+	prevSibling.AddChild(selected)
+	if prevSibling.Collapsed {
+		prevSibling.Collapsed = false
 	}
 
 	m.rebuildVisibleList()
@@ -320,6 +339,7 @@ func (m *Model) outdentBullet() {
 	if selected == nil || selected.Parent == nil {
 		return
 	}
+	m.recordUndo()
 
 	parent := selected.Parent
 	grandparent := parent.Parent
@@ -408,6 +428,7 @@ func (m *Model) moveBulletUp() {
 			return // Still no target found
 		}
 	}
+	m.recordUndo()
 
 	// Remove selected from its current parent
 	if selected.Parent == nil {
@@ -511,6 +532,7 @@ func (m *Model) moveBulletDown() {
 			return // Still no target found
 		}
 	}
+	m.recordUndo()
 
 	// Remove selected from its current parent
 	if selected.Parent == nil {
@@ -565,27 +587,67 @@ func (m *Model) zoomIn() {
 		return
 	}
 	
-	// Build breadcrumbs path to the selected bullet
-	var path []*Bullet
-	current := selected
-	for current != nil {
-		path = append([]*Bullet{current}, path...)
-		current = current.Parent
-	}
-	
-	// Remove the selected bullet from breadcrumbs (it becomes the zoomed view)
-	if len(path) > 0 {
+	m.pushJump()
+	m.zoomTo(selected)
+	m.visited(selected)
+}
+
+// zoomTo focuses the view on target, or on the whole outline when target is nil.
+func (m *Model) zoomTo(target *Bullet) {
+	if target == nil {
+		m.zoomedBullet = nil
+		m.breadcrumbs = make([]*Bullet, 0)
+	} else {
+		// Build breadcrumbs path to the target, without the target itself
+		// (it becomes the zoomed view)
+		path := target.GetPath()
 		m.breadcrumbs = path[:len(path)-1]
+		m.zoomedBullet = target
 	}
-	
-	m.zoomedBullet = selected
+
 	m.selectedIndex = 0
 	m.scrollOffset = 0
 	m.rebuildVisibleList()
 	m.ensureSelectedVisible()
 }
 
+// viewState records the zoom, selection and scroll position by bullet ID.
+func (m *Model) viewState() *ViewState {
+	view := &ViewState{ScrollOffset: m.scrollOffset}
+	if m.zoomedBullet != nil {
+		view.ZoomedID = m.zoomedBullet.ID
+	}
+	if selected := m.getSelectedBullet(); selected != nil && selected != m.pendingBullet() {
+		view.SelectedID = selected.ID
+	}
+	return view
+}
+
+// restoreView goes back to a saved view. Bullets that no longer exist are
+// skipped, leaving the view at the top level or the first bullet.
+func (m *Model) restoreView(view *ViewState) {
+	if view == nil {
+		return
+	}
+	if zoomed := findBulletByID(m.rootBullets, view.ZoomedID); zoomed != nil {
+		m.zoomTo(zoomed)
+	}
+	m.scrollOffset = view.ScrollOffset
+	if selected := findBulletByID(m.rootBullets, view.SelectedID); selected != nil {
+		m.selectBullet(selected)
+	}
+	if m.scrollOffset > m.selectedIndex {
+		m.scrollOffset = m.selectedIndex
+	}
+}
+
 func (m *Model) zoomOut() {
+	if m.zoomedBullet == nil {
+		return
+	}
+	from := m.zoomedBullet
+	m.pushJump()
+
 	if len(m.breadcrumbs) > 0 {
 		// Zoom out to parent
 		m.zoomedBullet = m.breadcrumbs[len(m.breadcrumbs)-1]
@@ -599,13 +661,47 @@ func (m *Model) zoomOut() {
 	m.selectedIndex = 0
 	m.scrollOffset = 0
 	m.rebuildVisibleList()
-	m.ensureSelectedVisible()
+	// Keep the bullet we came from selected
+	m.selectBullet(from)
 }
 
 func (m Model) Init() tea.Cmd {
 	return textinput.Blink
 }
 
+// mutatingKeys are the normal-mode keys that change the outline. They are
+// rejected in read-only mode, and ocli-ssh saves after handling them.
+var mutatingKeys = map[string]bool{
+	"enter":      true,
+	"e":          true,
+	"d":          true,
+	"tab":        true,
+	"shift+tab":  true,
+	"shift+up":   true,
+	"shift+down": true,
+	"c":          true,
+	"t":          true,
+	"x":          true,
+	"u":          true,
+	"ctrl+r":     true,
+	"a":          true,
+	"ctrl+s":     true, // Commits a note
+	"E":          true,
+	"D":          true,
+	"p":          true,
+	"P":          true,
+	"o":          true,
+	"O":          true,
+	"i":          true,
+	"I":          true,
+	"A":          true,
+	"m":          true,
+	"M":          true,
+	"*":          true,
+	">":          true,
+	"<":          true,
+}
+
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
@@ -613,13 +709,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.ensureSelectedVisible()
+		return m, nil
+
+	case editorFinishedMsg:
+		m.applyEditorResult(msg)
 		return m, nil
 
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
 	case tea.KeyMsg:
+		m.statusMessage = ""
+
 		if m.appMode == AppModeSettings {
 			switch msg.String() {
 			case "q", "esc", "s":
 				m.appMode = AppModeNormal
+				m.ensureSelectedVisible()
 				return m, nil
 				
 			case "up", "k":
@@ -628,7 +735,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				
 			case "down", "j":
-				if m.settingsIndex < 0 { // We only have 1 setting for now
+				if m.settingsIndex < 2 { // Hierarchy lines, notes and trash retention
 					m.settingsIndex++
 				}
 				
@@ -636,9 +743,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				switch m.settingsIndex {
 				case 0: // Toggle hierarchy lines
 					m.settings.ShowHierarchyLines = !m.settings.ShowHierarchyLines
-					// Auto-save after settings change
-					m.saveData()
+				case 1: // Toggle notes for every bullet
+					m.settings.ShowAllNotes = !m.settings.ShowAllNotes
+				case 2: // Cycle how long deleted bullets are kept
+					m.settings.TrashRetentionDays = nextRetention(m.settings.TrashRetentionDays)
 				}
+				// Auto-save after settings change
+				m.saveData()
 			}
 			return m, nil
 		}
@@ -651,16 +762,58 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		}
+
+		if m.appMode == AppModeTrash {
+			return m.updateTrash(msg)
+		}
+
+		if m.appMode == AppModePicker {
+			return m.updatePicker(msg)
+		}
+
+		if m.appMode == AppModeBookmarks {
+			return m.updateBookmarks(msg)
+		}
+
+		if m.appMode == AppModeStats {
+			switch msg.String() {
+			case "q", "esc", "#":
+				m.appMode = AppModeNormal
+				return m, nil
+			}
+			return m, nil
+		}
 		
+		if m.editMode == EditModeNote {
+			return m.updateNoteEditor(msg)
+		}
+
+		if m.editMode == EditModeSearch {
+			return m.updateSearch(msg)
+		}
+
+		if m.editMode == EditModeFilter {
+			return m.updateFilter(msg)
+		}
+
 		if m.editMode != EditModeNone {
 			switch msg.String() {
 			case "enter":
 				content := m.textInput.Value()
+				if m.editMode == EditModeEdit && m.editingBullet != nil && m.textInput.Position() < len([]rune(content)) {
+					// Enter inside the text splits the bullet at the cursor
+					return m, m.splitBullet()
+				}
+				if m.editMode == EditModeNew && content != "" {
+					// Rapid entry: commit and continue with the next sibling
+					return m, m.commitInsert(content)
+				}
 				if m.editMode == EditModeNew {
-					if content != "" {
-						m.addNewBullet(content)
-					}
+					m.cancelInsert()
 				} else if m.editMode == EditModeEdit && m.editingBullet != nil {
+					if content != m.editingBullet.Content {
+						m.recordUndo()
+					}
 					m.editingBullet.Content = content
 					m.editingBullet.IsEditing = false
 					// Auto-save after editing content
@@ -672,7 +825,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.textInput.Blur()
 				return m, nil
 
+			case "backspace":
+				if m.editMode == EditModeEdit && m.editingBullet != nil && m.textInput.Position() == 0 {
+					// Backspace at the start joins the bullet onto the previous one
+					return m, m.joinBullet()
+				}
+				m.textInput, cmd = m.textInput.Update(msg)
+				return m, cmd
+
 			case "esc":
+				if m.editMode == EditModeNew {
+					m.cancelInsert()
+				}
 				m.editMode = EditModeNone
 				if m.editingBullet != nil {
 					m.editingBullet.IsEditing = false
@@ -688,6 +852,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Folding only changes the view, so it is allowed when read-only
+		if m.pendingKey == "z" {
+			m.pendingKey = ""
+			m.fold(msg.String())
+			return m, nil
+		}
+
+		if m.readOnly && mutatingKeys[msg.String()] {
+			m.count = 0
+			m.statusMessage = "Read-only: changes are disabled"
+			return m, nil
+		}
+
+		if m.visualAnchor != nil {
+			if _, _, ok := m.visualRange(); ok {
+				return m.updateVisual(msg)
+			}
+			m.stopVisual()
+		}
+
+		// A count prefix applies to the next key
+		if isCountDigit(msg.String(), m.count) {
+			m.count = m.count*10 + int(msg.String()[0]-'0')
+			return m, nil
+		}
+		count := m.count
+		m.count = 0
+		if count > 1 && repeatableKeys[msg.String()] {
+			return m.repeatKey(msg, count)
+		}
+
 		switch msg.String() {
 		case "q", "ctrl+c":
 			// Save data before quitting
@@ -706,30 +901,61 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.ensureSelectedVisible()
 			}
 
-		case "enter":
-			m.editMode = EditModeNew
-			m.textInput.SetValue("")
-			m.textInput.Focus()
-			return m, textinput.Blink
+		case "pgup":
+			m.moveSelection(-m.pageSize())
+
+		case "pgdown":
+			m.moveSelection(m.pageSize())
+
+		case "ctrl+u":
+			m.moveSelection(-m.pageSize() / 2)
+
+		case "ctrl+d":
+			m.moveSelection(m.pageSize() / 2)
+
+		case "g":
+			m.selectIndex(0)
+
+		case "G":
+			if count > 0 {
+				m.selectIndex(count - 1)
+			} else {
+				m.selectIndex(len(m.allBullets) - 1)
+			}
+
+		case "{":
+			m.selectParent()
+
+		case "[", "]":
+			m.selectSibling(msg.String() == "]")
+
+		case "(", ")":
+			m.selectChild(msg.String() == ")")
+
+		case "enter", "o":
+			return m, m.startInsert(insertBelow)
+
+		case "O":
+			return m, m.startInsert(insertAbove)
+
+		case "i":
+			return m, m.startInsert(insertFirstChild)
+
+		case "I":
+			return m, m.startInsert(insertLastChild)
 
 		case "e":
 			if selected := m.getSelectedBullet(); selected != nil {
-				m.editMode = EditModeEdit
-				m.editingBullet = selected
-				selected.IsEditing = true
-				m.textInput.SetValue(selected.Content)
-				m.textInput.Focus()
-				m.textInput.SetCursor(len(selected.Content))
-				return m, textinput.Blink
+				return m, m.startEdit(selected, len([]rune(selected.Content)))
 			}
 
 		case "d":
 			m.deleteBullet()
 
-		case "tab":
+		case "tab", ">":
 			m.indentBullet()
 
-		case "shift+tab":
+		case "shift+tab", "<":
 			m.outdentBullet()
 
 		case " ", "space":
@@ -747,18 +973,110 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "c":
 			if selected := m.getSelectedBullet(); selected != nil {
+				m.recordUndo()
 				selected.CycleColor()
 			}
 
 		case "t":
 			if selected := m.getSelectedBullet(); selected != nil {
+				m.recordUndo()
 				selected.ToggleTask()
 			}
 
 		case "x":
-			if selected := m.getSelectedBullet(); selected != nil {
+			if selected := m.getSelectedBullet(); selected != nil && selected.IsTask {
+				m.recordUndo()
 				selected.ToggleComplete()
 			}
+
+		case "a":
+			if selected := m.getSelectedBullet(); selected != nil {
+				return m, m.startNoteEdit(selected)
+			}
+
+		case "E":
+			if selected := m.getSelectedBullet(); selected != nil {
+				return m, m.openInEditor(selected)
+			}
+
+		case "V":
+			m.startVisual()
+
+		case "/":
+			return m, m.openSearch()
+
+		case "ctrl+p":
+			return m, m.openSwitcher()
+
+		case "*":
+			m.toggleBookmark()
+
+		case "b":
+			m.appMode = AppModeBookmarks
+			m.bookmarkIndex = 0
+
+		case "ctrl+o", "alt+left":
+			m.jumpHistory(false)
+
+		case "alt+right":
+			m.jumpHistory(true)
+
+		case "z":
+			m.pendingKey = "z"
+
+		case "f":
+			return m, m.openFilter()
+
+		case "F":
+			if m.filter != nil {
+				m.clearFilter()
+			}
+
+		case "n":
+			if m.search != nil {
+				m.jumpToMatch(true)
+			}
+
+		case "N":
+			if m.search != nil {
+				m.jumpToMatch(false)
+			}
+
+		case "esc":
+			m.search = nil
+
+		case "y":
+			m.yank()
+
+		case "D":
+			m.cut()
+
+		case "p":
+			m.put(false)
+
+		case "P":
+			m.put(true)
+
+		case "A":
+			if selected := m.getSelectedBullet(); selected != nil {
+				m.archiveBullets([]*Bullet{selected})
+			}
+
+		case "T":
+			m.appMode = AppModeTrash
+			m.trashIndex = 0
+
+		case "m":
+			return m, m.openRefile(pickMoveTo)
+
+		case "M":
+			return m, m.openRefile(pickDuplicateTo)
+
+		case "u":
+			m.undo()
+
+		case "ctrl+r":
+			m.redo()
 			
 		case "s":
 			m.appMode = AppModeSettings
@@ -767,6 +1085,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "h":
 			m.appMode = AppModeHelp
 			
+		case "#":
+			m.appMode = AppModeStats
+			
 		case "right":
 			m.zoomIn()
 			
@@ -805,7 +1126,27 @@ func (m Model) View() string {
 		return m.renderHelp(appStyle, titleStyle)
 	}
 	
-	contentBuilder.WriteString(titleStyle.Render("OCLI"))
+	if m.appMode == AppModeStats {
+		return m.renderStats(appStyle, titleStyle)
+	}
+
+	if m.appMode == AppModeTrash {
+		return m.renderTrash(appStyle, titleStyle)
+	}
+
+	if m.appMode == AppModePicker {
+		return m.renderPicker(appStyle, titleStyle)
+	}
+
+	if m.appMode == AppModeBookmarks {
+		return m.renderBookmarks(appStyle, titleStyle)
+	}
+	
+	title := "OCLI"
+	if m.readOnly {
+		title += " (read-only)"
+	}
+	contentBuilder.WriteString(titleStyle.Render(title))
 	
 	// Show breadcrumbs when zoomed
 	if m.zoomedBullet != nil {
@@ -832,10 +1173,133 @@ func (m Model) View() string {
 		contentBuilder.WriteString("\n\n")
 	}
 
-	if m.editMode == EditModeNew {
-		contentBuilder.WriteString("New bullet: " + m.textInput.View() + "\n\n")
+	// Pin the ancestors of the top bullet when they are scrolled away
+	for _, line := range m.renderSticky() {
+		contentBuilder.WriteString(line)
+		contentBuilder.WriteString("\n")
+	}
+
+	// Only render the bullets that fit in the viewport
+	availableHeight := m.listHeight()
+	usedLines := 0
+	endIndex := m.scrollOffset
+	for i := m.scrollOffset; i < len(m.allBullets) && usedLines < availableHeight; i++ {
+		for _, line := range m.renderBullet(i) {
+			if usedLines == availableHeight {
+				break
+			}
+			contentBuilder.WriteString(line)
+			contentBuilder.WriteString("\n")
+			usedLines++
+		}
+		endIndex = i + 1
 	}
 
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		MarginTop(2)
+
+	help := "\n'h' for help • 's' for settings"
+	if len(m.register) > 0 {
+		help += " • register: " + m.registerSummary()
+	}
+	if first, last, ok := m.visualRange(); ok {
+		help = fmt.Sprintf("\n-- VISUAL -- %d selected • tab/shift+tab • shift+↑↓ • c t x d • esc to exit", last-first+1)
+	}
+	if m.count > 0 {
+		help = fmt.Sprintf("\n%d", m.count)
+	}
+	if m.pendingKey == "z" {
+		help = "\n" + foldHelp
+	}
+	if m.filter != nil {
+		help = "\n" + m.filterLine()
+	}
+	if m.search != nil && m.editMode != EditModeFilter {
+		help = "\n" + m.searchLine()
+	}
+	if m.statusMessage != "" && m.editMode != EditModeSearch && m.editMode != EditModeFilter {
+		help = "\n" + m.statusMessage
+	}
+	
+	// Add scroll indicators if there's more content
+	if m.scrollOffset > 0 || endIndex < len(m.allBullets) {
+		totalItems := len(m.allBullets)
+		visibleStart := m.scrollOffset + 1
+		visibleEnd := endIndex
+		if visibleEnd > totalItems {
+			visibleEnd = totalItems
+		}
+		
+		scrollInfo := fmt.Sprintf(" • %d-%d of %d", visibleStart, visibleEnd, totalItems)
+		help += scrollInfo
+	}
+	
+	contentBuilder.WriteString(helpStyle.Render(help))
+
+	// Apply padding to the entire content
+	s.WriteString(appStyle.Render(contentBuilder.String()))
+
+	return s.String()
+}
+
+// bulletDepth returns b's depth relative to the zoom level.
+func (m Model) bulletDepth(b *Bullet) int {
+	if m.zoomedBullet != nil {
+		return b.GetDepthFrom(m.zoomedBullet)
+	}
+	return b.GetDepth()
+}
+
+// bulletPrefix returns the caret, checkbox or bullet drawn before the content.
+func bulletPrefix(bullet *Bullet) string {
+	prefix := ""
+
+	// Handle caret for items with children
+	if len(bullet.Children) > 0 {
+		if bullet.Collapsed {
+			prefix = "▶ "
+		} else {
+			prefix = "▼ "
+		}
+	}
+
+	// Handle task checkbox or bullet
+	if bullet.IsTask {
+		if bullet.Completed {
+			prefix += "☑ "
+		} else {
+			prefix += "☐ "
+		}
+	} else {
+		// Only show bullet if there's no caret
+		if len(bullet.Children) == 0 {
+			prefix = "• "
+		}
+	}
+
+	return prefix
+}
+
+// contentWidth is the room left for a bullet's text after the app padding,
+// indentation and prefix. Zero means the width is unknown and text is not wrapped.
+func (m Model) contentWidth(depth, prefixWidth int) int {
+	if m.width == 0 {
+		return 0
+	}
+	width := m.width - 2 - 4*depth - prefixWidth
+	if width < 10 {
+		width = 10
+	}
+	return width
+}
+
+// renderBullet renders the bullet at index i of the visible list: its
+// content soft-wrapped under its own indentation, followed by its note when
+// that is shown.
+func (m Model) renderBullet(i int) []string {
+	bullet := m.allBullets[i]
+
 	// Define color styles
 	colorStyles := map[BulletColor]lipgloss.Style{
 		ColorDefault: lipgloss.NewStyle().Foreground(lipgloss.Color("252")),
@@ -852,141 +1316,162 @@ func (m Model) View() string {
 	// Style for vertical hierarchy lines
 	lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 
-	// Calculate available space for content
-	availableHeight := m.height - 6 // Title (2 lines) + breadcrumbs (2 lines) + help (2 lines)
-	if m.editMode == EditModeNew {
-		availableHeight -= 2 // New bullet input
+	var indent string
+	depth := m.bulletDepth(bullet)
+	
+	if m.settings.ShowHierarchyLines {
+		// Build hierarchy lines
+		var hierarchyLines strings.Builder
+		
+		// Add vertical lines for each level of indentation
+		for level := 0; level < depth; level++ {
+			if level == depth-1 {
+				// Last level - use a branch character
+				hierarchyLines.WriteString(lineStyle.Render("├── "))
+			} else {
+				// Not the last level - use a vertical line with spacing
+				hierarchyLines.WriteString(lineStyle.Render("│   "))
+			}
+		}
+		
+		indent = hierarchyLines.String()
+	} else {
+		// Simple indentation without hierarchy lines
+		indent = strings.Repeat("    ", depth)
 	}
 
-	// Calculate visible range
-	startIndex := m.scrollOffset
-	endIndex := m.scrollOffset + availableHeight
-	if endIndex > len(m.allBullets) {
-		endIndex = len(m.allBullets)
+	prefix := bulletPrefix(bullet)
+	prefixWidth := lipgloss.Width(prefix)
+
+	editing := bullet.IsEditing && (m.editMode == EditModeEdit || m.editMode == EditModeNew)
+
+	// Completed tasks are dimmed including their prefix; otherwise the
+	// bullet's color applies to the content only
+	contentStyle := colorStyles[bullet.Color]
+	styledPrefix := prefix
+	if bullet.IsTask && bullet.Completed {
+		contentStyle = completedStyle
+		styledPrefix = completedStyle.Render(prefix)
+	}
+	if m.inVisualRange(i) {
+		contentStyle = contentStyle.Copy().Background(lipgloss.Color("238"))
+	}
+	if i == m.selectedIndex && !editing {
+		// For selected items, apply underline only to content, preserve
+		// original styling. The text input pads to its width, so it is
+		// left plain while editing.
+		contentStyle = contentStyle.Copy().Underline(true)
 	}
 
-	// Only render visible bullets
-	for i := startIndex; i < endIndex; i++ {
-		bullet := m.allBullets[i]
-		var indent string
-		var depth int
-		
-		// Calculate depth relative to zoom level
-		if m.zoomedBullet != nil {
-			depth = bullet.GetDepthFrom(m.zoomedBullet)
-		} else {
-			depth = bullet.GetDepth()
-		}
-		
-		if m.settings.ShowHierarchyLines {
-			// Build hierarchy lines
-			var hierarchyLines strings.Builder
-			
-			// Add vertical lines for each level of indentation
-			for level := 0; level < depth; level++ {
-				if level == depth-1 {
-					// Last level - use a branch character
-					hierarchyLines.WriteString(lineStyle.Render("├── "))
-				} else {
-					// Not the last level - use a vertical line with spacing
-					hierarchyLines.WriteString(lineStyle.Render("│   "))
-				}
-			}
-			
-			indent = hierarchyLines.String()
+	// Markers follow the text on its last line, so the text is wrapped short
+	// enough to leave room for them. The text input already fills the width,
+	// so they are left out while editing.
+	var markers string
+	if !editing && m.isBookmarked(bullet) {
+		markers += lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Render(" ★")
+	}
+	if !editing && bullet.Note != "" && !m.noteShown(bullet) {
+		// Hint that there is a note to read
+		markers += lineStyle.Render(" ✎")
+	}
+
+	var contentLines []string
+	if editing {
+		// The text input scrolls horizontally within the content width
+		contentLines = []string{m.highlightMatches(m.textInput.View(), contentStyle)}
+	} else {
+		width := m.contentWidth(depth, prefixWidth) - lipgloss.Width(markers)
+		contentLines = m.highlightWrapped(bullet.Content, width, contentStyle)
+	}
+
+	continuation := m.continuationIndent(depth, prefixWidth)
+	lines := make([]string, 0, len(contentLines))
+	for n, contentLine := range contentLines {
+		if n == 0 {
+			lines = append(lines, fmt.Sprintf("%s%s%s", indent, styledPrefix, contentLine))
 		} else {
-			// Simple indentation without hierarchy lines
-			indent = strings.Repeat("    ", depth)
+			lines = append(lines, continuation+contentLine)
 		}
+	}
 
-		prefix := ""
+	lines[len(lines)-1] += markers
 
-		// Handle caret for items with children
-		if len(bullet.Children) > 0 {
-			if bullet.Collapsed {
-				prefix = "▶ "
-			} else {
-				prefix = "▼ "
-			}
-		}
+	for _, noteLine := range m.noteLines(bullet) {
+		lines = append(lines, continuation+noteLine)
+	}
+	return lines
+}
 
-		// Handle task checkbox or bullet
-		if bullet.IsTask {
-			if bullet.Completed {
-				prefix += "☑ "
-			} else {
-				prefix += "☐ "
-			}
-		} else {
-			// Only show bullet if there's no caret
-			if len(bullet.Children) == 0 {
-				prefix = "• "
-			}
-		}
+// wrapText soft-wraps s at word boundaries so that no line is wider than
+// width cells, breaking words that are longer than a whole line.
+func wrapText(s string, width int) []string {
+	var lines []string
+	for _, span := range wrapSpans(s, width) {
+		lines = append(lines, s[span[0]:span[1]])
+	}
+	return lines
+}
 
-		content := bullet.Content
-		if bullet.IsEditing && m.editMode == EditModeEdit {
-			content = m.textInput.View()
-		}
+// wrapSpans does the wrapping for wrapText, returning each line as a byte
+// range of s so that positions in s can be mapped onto the wrapped lines.
+func wrapSpans(s string, width int) [][2]int {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return [][2]int{{0, len(s)}}
+	}
 
-		// Build the line with proper styling
-		if i == m.selectedIndex {
-			// For selected items, apply underline only to content, preserve original styling
-			var baseStyle lipgloss.Style
-			if bullet.IsTask && bullet.Completed {
-				baseStyle = completedStyle
-				// Also apply completed style to prefix for completed tasks
-				styledPrefix := completedStyle.Render(prefix)
-				styledContent := baseStyle.Copy().Underline(true).Render(content)
-				line := fmt.Sprintf("%s%s%s", indent, styledPrefix, styledContent)
-				contentBuilder.WriteString(line)
-			} else {
-				baseStyle = colorStyles[bullet.Color]
-				// Apply underline to the content only
-				styledContent := baseStyle.Copy().Underline(true).Render(content)
-				line := fmt.Sprintf("%s%s%s", indent, prefix, styledContent)
-				contentBuilder.WriteString(line)
+	var spans [][2]int
+	lineStart, lineEnd := -1, -1
+	for _, word := range wordSpans(s) {
+		wordStart, wordEnd := word[0], word[1]
+		if lineStart >= 0 && lipgloss.Width(s[lineStart:wordEnd]) <= width {
+			lineEnd = wordEnd
+			continue
+		}
+		if lineStart >= 0 {
+			spans = append(spans, [2]int{lineStart, lineEnd})
+			lineStart = -1
+		}
+		// Hard-break words that do not fit on a line of their own
+		for lipgloss.Width(s[wordStart:wordEnd]) > width {
+			cut, cutWidth := wordStart, 0
+			for _, r := range s[wordStart:wordEnd] {
+				runeWidth := lipgloss.Width(string(r))
+				if cut > wordStart && cutWidth+runeWidth > width {
+					break
+				}
+				cut += utf8.RuneLen(r)
+				cutWidth += runeWidth
 			}
-		} else if bullet.IsTask && bullet.Completed {
-			// Apply completed style to both prefix and content
-			styledPrefix := completedStyle.Render(prefix)
-			styledContent := completedStyle.Render(content)
-			line := fmt.Sprintf("%s%s%s", indent, styledPrefix, styledContent)
-			contentBuilder.WriteString(line)
-		} else {
-			// Apply color based on bullet's color property only to content
-			styledContent := colorStyles[bullet.Color].Render(content)
-			line := fmt.Sprintf("%s%s%s", indent, prefix, styledContent)
-			contentBuilder.WriteString(line)
+			spans = append(spans, [2]int{wordStart, cut})
+			wordStart = cut
 		}
-		contentBuilder.WriteString("\n")
+		lineStart, lineEnd = wordStart, wordEnd
 	}
+	if lineStart >= 0 {
+		spans = append(spans, [2]int{lineStart, lineEnd})
+	} else if len(spans) == 0 {
+		spans = append(spans, [2]int{0, 0})
+	}
+	return spans
+}
 
-	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
-		MarginTop(2)
-
-	help := "\n'h' for help • 's' for settings"
-	
-	// Add scroll indicators if there's more content
-	if len(m.allBullets) > availableHeight {
-		totalItems := len(m.allBullets)
-		visibleStart := m.scrollOffset + 1
-		visibleEnd := endIndex
-		if visibleEnd > totalItems {
-			visibleEnd = totalItems
+// wordSpans returns the byte ranges of the whitespace-separated words in s.
+func wordSpans(s string) [][2]int {
+	var words [][2]int
+	start := -1
+	for i, r := range s {
+		switch {
+		case unicode.IsSpace(r) && start >= 0:
+			words = append(words, [2]int{start, i})
+			start = -1
+		case !unicode.IsSpace(r) && start < 0:
+			start = i
 		}
-		
-		scrollInfo := fmt.Sprintf(" • %d-%d of %d", visibleStart, visibleEnd, totalItems)
-		help += scrollInfo
 	}
-	
-	contentBuilder.WriteString(helpStyle.Render(help))
-
-	// Apply padding to the entire content
-	s.WriteString(appStyle.Render(contentBuilder.String()))
-
-	return s.String()
+	if start >= 0 {
+		words = append(words, [2]int{start, len(s)})
+	}
+	return words
 }
 
 func (m Model) renderSettings(appStyle, titleStyle lipgloss.Style) string {
@@ -1001,20 +1486,16 @@ func (m Model) renderSettings(appStyle, titleStyle lipgloss.Style) string {
 		Underline(true)
 	
 	settings := []struct {
-		name   string
-		value  bool
-		toggle *bool
+		name  string
+		value string
 	}{
-		{"Show hierarchy lines", m.settings.ShowHierarchyLines, &m.settings.ShowHierarchyLines},
+		{"Show hierarchy lines", onOff(m.settings.ShowHierarchyLines)},
+		{"Show notes of all bullets", onOff(m.settings.ShowAllNotes)},
+		{"Empty trash after", retentionLabel(m.settings.TrashRetentionDays)},
 	}
 	
 	for i, setting := range settings {
-		status := "off"
-		if setting.value {
-			status = "on"
-		}
-		
-		line := fmt.Sprintf("%s: %s", setting.name, status)
+		line := fmt.Sprintf("%s: %s", setting.name, setting.value)
 		
 		if i == m.settingsIndex {
 			contentBuilder.WriteString(selectedSettingStyle.Render(line))
@@ -1053,25 +1534,57 @@ func (m Model) renderHelp(appStyle, titleStyle lipgloss.Style) string {
 			"Navigation",
 			[]string{
 				"↑↓ or j/k    Navigate up/down",
+				"PgUp/PgDn   Page up/down (Ctrl+U/Ctrl+D half a page)",
+				"g / G       First / last bullet (5G: the fifth)",
+				"{           Parent",
+				"[ / ]       Previous / next sibling",
+				"( / )       First / last child",
+				"5j, 3>      A count repeats a motion or command",
 				"←           Zoom out", 
 				"→           Zoom in",
+				"Ctrl+O      Back to the previous location (also Alt+←)",
+				"Alt+→       Forward again",
+				"Ctrl+P      Go to any bullet (fuzzy path search)",
+				"*           Star / unstar bullet",
+				"b           Bookmarks (1-9 jump to a starred bullet)",
+				"/           Search content and notes (Alt+C case, Alt+R regex)",
+				"n / N       Next / previous match (Esc clears)",
+				"f / F       Filter the view to matches / clear the filter",
+				"            Archived bullets are counted, not shown (T, Tab)",
 			},
 		},
 		{
 			"Editing",
 			[]string{
-				"Enter       Create new bullet",
+				"Enter / o   New bullet below (Enter again adds the next one)",
+				"O           New bullet above",
+				"i / I       New first / last child",
 				"e           Edit selected bullet",
-				"d           Delete selected bullet",
+				"            (Enter mid-text splits, Backspace at start joins)",
+				"a           Add/edit note (Ctrl+S save, Esc cancel)",
+				"E           Edit subtree in $EDITOR",
+				"d           Move selected bullet to the trash",
+				"A           Archive a finished branch",
+				"T           Browse trash and archive (Enter restores)",
+				"u           Undo",
+				"Ctrl+R      Redo",
 			},
 		},
 		{
 			"Organization", 
 			[]string{
-				"Tab         Indent (move right)",
-				"Shift+Tab   Outdent (move left)", 
+				"Tab         Indent (move right, also >)",
+				"Shift+Tab   Outdent (move left, also <)", 
 				"Shift+↑↓    Move bullet up/down",
 				"Space       Collapse/expand",
+				"zM / zR     Collapse / expand all",
+				"zO / zC     Expand whole branch / collapse its siblings",
+				"z1-z9       Show only that many levels",
+				"m / M       Move / duplicate to… (fuzzy picker)",
+				"V           Select a range (Tab, Shift+↑↓, c, t, x, d, y, D, A, m, M apply to all)",
+				"y           Yank (copy) bullet and its children",
+				"D           Cut bullet and its children",
+				"p / P       Put after / as last child of selected bullet",
 			},
 		},
 		{
@@ -1087,7 +1600,9 @@ func (m Model) renderHelp(appStyle, titleStyle lipgloss.Style) string {
 			[]string{
 				"h           Show this help",
 				"s           Open settings",
+				"#           Show outline statistics",
 				"q           Quit application",
+				"Mouse       Click selects, double-click edits, wheel scrolls",
 			},
 		},
 	}
@@ -1113,3 +1628,47 @@ func (m Model) renderHelp(appStyle, titleStyle lipgloss.Style) string {
 	
 	return appStyle.Render(contentBuilder.String())
 }
+
+func (m Model) renderStats(appStyle, titleStyle lipgloss.Style) string {
+	var contentBuilder strings.Builder
+
+	// Statistics cover the zoomed bullet's children, or the whole outline
+	var stats OutlineStats
+	if m.zoomedBullet != nil {
+		stats = computeStats(m.zoomedBullet.Children, m.zoomedBullet)
+	} else {
+		stats = computeStats(m.rootBullets, nil)
+	}
+
+	contentBuilder.WriteString(titleStyle.Render("Statistics"))
+	contentBuilder.WriteString("\n\n")
+
+	itemStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+	sectionStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("39")).
+		Bold(true)
+
+	for _, section := range stats.sections() {
+		if len(section.items) == 0 {
+			continue
+		}
+		contentBuilder.WriteString(sectionStyle.Render(section.title))
+		contentBuilder.WriteString("\n")
+
+		for _, item := range section.items {
+			contentBuilder.WriteString("  ")
+			contentBuilder.WriteString(itemStyle.Render(item))
+			contentBuilder.WriteString("\n")
+		}
+		contentBuilder.WriteString("\n")
+	}
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		MarginTop(1)
+
+	footer := "Press '#', 'esc', or 'q' to return"
+	contentBuilder.WriteString(footerStyle.Render(footer))
+
+	return appStyle.Render(contentBuilder.String())
+}
@@ -0,0 +1,116 @@
+package main
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// repeatableKeys take a count prefix, as in 5j or 3>, and run that many times.
+var repeatableKeys = map[string]bool{
+	"up": true, "k": true, "down": true, "j": true,
+	"pgup": true, "pgdown": true, "ctrl+u": true, "ctrl+d": true,
+	"{": true, "[": true, "]": true,
+	"<": true, ">": true, "tab": true, "shift+tab": true,
+	"shift+up": true, "shift+down": true,
+}
+
+// isCountDigit reports whether key extends the count prefix. A leading 0 is
+// not a count.
+func isCountDigit(key string, count int) bool {
+	return len(key) == 1 && key[0] >= '0' && key[0] <= '9' && (count > 0 || key != "0")
+}
+
+// repeatKey handles msg count times. The repetitions are undone as one step:
+// they record into an empty stack, and only the first snapshot, taken before
+// any of them, is put back on the history.
+func (m Model) repeatKey(msg tea.KeyMsg, count int) (tea.Model, tea.Cmd) {
+	history := m.undoStack
+	m.undoStack = nil
+	for i := 0; i < count; i++ {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	if len(m.undoStack) == 0 {
+		m.undoStack = history
+		return m, nil
+	}
+
+	m.undoStack = append(append([]undoState(nil), history...), m.undoStack[0])
+	if len(m.undoStack) > maxUndoHistory {
+		m.undoStack = m.undoStack[len(m.undoStack)-maxUndoHistory:]
+	}
+	// Persist the merged history rather than the partial one
+	m.saveData()
+	return m, nil
+}
+
+// moveSelection moves the selection by delta rows, stopping at either end.
+func (m *Model) moveSelection(delta int) {
+	m.selectIndex(m.selectedIndex + delta)
+}
+
+// selectIndex selects the visible bullet at index, clamped to the list.
+func (m *Model) selectIndex(index int) {
+	m.selectedIndex = index
+	m.clampSelection()
+}
+
+// pageSize is how many rows PgUp/PgDn move, one screen of content.
+func (m *Model) pageSize() int {
+	if rows := m.contentHeight(); rows > 1 {
+		return rows
+	}
+	return 1
+}
+
+// selectParent moves to the selected bullet's parent if it is shown.
+func (m *Model) selectParent() {
+	if selected := m.getSelectedBullet(); selected != nil && selected != m.zoomedBullet && selected.Parent != nil {
+		m.selectBullet(selected.Parent)
+	}
+}
+
+// selectSibling moves to the next or previous sibling that is shown.
+func (m *Model) selectSibling(next bool) {
+	selected := m.getSelectedBullet()
+	if selected == nil || selected == m.zoomedBullet {
+		return
+	}
+	siblings := m.siblingsOf(selected)
+	step := -1
+	if next {
+		step = 1
+	}
+	for i := indexOf(siblings, selected) + step; i >= 0 && i < len(siblings); i += step {
+		if indexOf(m.allBullets, siblings[i]) >= 0 {
+			m.selectBullet(siblings[i])
+			return
+		}
+	}
+}
+
+// selectChild moves to the first or last child, expanding the selected
+// bullet if it is collapsed.
+func (m *Model) selectChild(last bool) {
+	selected := m.getSelectedBullet()
+	if selected == nil || len(selected.Children) == 0 {
+		return
+	}
+	if selected.Collapsed {
+		selected.Collapsed = false
+		m.rebuildVisibleList()
+	}
+	children := selected.Children
+	if last {
+		for i := len(children) - 1; i >= 0; i-- {
+			if indexOf(m.allBullets, children[i]) >= 0 {
+				m.selectBullet(children[i])
+				return
+			}
+		}
+		return
+	}
+	for _, child := range children {
+		if indexOf(m.allBullets, child) >= 0 {
+			m.selectBullet(child)
+			return
+		}
+	}
+}
@@ -5,16 +5,30 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 type AppData struct {
-	RootBullets []*Bullet `json:"rootBullets"`
-	Settings    Settings  `json:"settings"`
+	RootBullets []*Bullet        `json:"rootBullets"`
+	Settings    Settings         `json:"settings"`
+	ReadOnly    bool             `json:"readOnly,omitempty"` // Open this file without allowing changes
+	Trash       []*RemovedBullet `json:"trash,omitempty"`
+	Archive     []*RemovedBullet `json:"archive,omitempty"`
+	Bookmarks   []string         `json:"bookmarks,omitempty"` // IDs of starred bullets
+	View        *ViewState       `json:"view,omitempty"`
+}
+
+// ViewState is where the user was in the outline, restored on the next start.
+type ViewState struct {
+	ZoomedID     string `json:"zoomedId,omitempty"`
+	SelectedID   string `json:"selectedId,omitempty"`
+	ScrollOffset int    `json:"scrollOffset,omitempty"`
 }
 
 type ConfigManager struct {
-	configDir  string
-	configFile string
+	configDir   string
+	configFile  string
+	historyFile string
 }
 
 func NewConfigManager() (*ConfigManager, error) {
@@ -32,11 +46,52 @@ func NewConfigManager() (*ConfigManager, error) {
 	}
 
 	return &ConfigManager{
-		configDir:  configDir,
-		configFile: configFile,
+		configDir:   configDir,
+		configFile:  configFile,
+		historyFile: filepath.Join(configDir, "history.json"),
 	}, nil
 }
 
+// SaveHistory writes the persisted part of the undo stack.
+func (cm *ConfigManager) SaveHistory(history *undoHistory) error {
+	if cm.historyFile == "" {
+		return nil
+	}
+
+	jsonBytes, err := json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("failed to marshal history: %w", err)
+	}
+
+	if err := os.WriteFile(cm.historyFile, jsonBytes, 0644); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadHistory reads the undo stack saved by the previous session, if any.
+func (cm *ConfigManager) LoadHistory() (*undoHistory, error) {
+	if cm.historyFile == "" {
+		return nil, nil
+	}
+
+	jsonBytes, err := os.ReadFile(cm.historyFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	var history undoHistory
+	if err := json.Unmarshal(jsonBytes, &history); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal history: %w", err)
+	}
+
+	return &history, nil
+}
+
 func (cm *ConfigManager) Save(data *AppData) error {
 	// Convert bullets to JSON-serializable format (remove parent references to avoid cycles)
 	jsonData := cm.prepareForSerialization(data)
@@ -61,6 +116,20 @@ func (cm *ConfigManager) Load() (*AppData, error) {
 		return cm.createDefaultData(), nil
 	}
 
+	data, err := cm.readDataFile()
+	if err != nil {
+		return nil, err
+	}
+
+	// Restore parent relationships after loading
+	cm.restoreParentRelationships(data)
+
+	return data, nil
+}
+
+// readDataFile decodes the data file exactly as stored, without fixing up
+// parent references or editing state.
+func (cm *ConfigManager) readDataFile() (*AppData, error) {
 	jsonBytes, err := os.ReadFile(cm.configFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
@@ -71,17 +140,35 @@ func (cm *ConfigManager) Load() (*AppData, error) {
 		return nil, fmt.Errorf("failed to unmarshal data: %w", err)
 	}
 
-	// Restore parent relationships after loading
-	cm.restoreParentRelationships(&data)
-
 	return &data, nil
 }
 
+// Backup copies the current data file next to itself with a timestamp suffix
+// and returns the backup's path.
+func (cm *ConfigManager) Backup() (string, error) {
+	jsonBytes, err := os.ReadFile(cm.configFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	backupFile := cm.configFile + ".bak-" + time.Now().Format("20060102-150405")
+	if err := os.WriteFile(backupFile, jsonBytes, 0644); err != nil {
+		return "", fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	return backupFile, nil
+}
+
 func (cm *ConfigManager) prepareForSerialization(data *AppData) *AppData {
 	// Deep copy the data and remove parent references to avoid circular dependencies
 	serializedData := &AppData{
 		RootBullets: make([]*Bullet, len(data.RootBullets)),
 		Settings:    data.Settings,
+		ReadOnly:    data.ReadOnly,
+		Trash:       copyRemoved(data.Trash),
+		Archive:     copyRemoved(data.Archive),
+		Bookmarks:   data.Bookmarks,
+		View:        data.View,
 	}
 
 	for i, bullet := range data.RootBullets {
@@ -95,6 +182,7 @@ func (cm *ConfigManager) copyBulletForSerialization(bullet *Bullet) *Bullet {
 	copy := &Bullet{
 		ID:        bullet.ID,
 		Content:   bullet.Content,
+		Note:      bullet.Note,
 		Children:  make([]*Bullet, len(bullet.Children)),
 		Parent:    nil, // Remove parent reference to avoid cycles
 		Collapsed: bullet.Collapsed,
@@ -155,6 +243,7 @@ func (cm *ConfigManager) createDefaultData() *AppData {
 		RootBullets: []*Bullet{welcome},
 		Settings: Settings{
 			ShowHierarchyLines: true,
+			TrashRetentionDays: defaultTrashRetentionDays,
 		},
 	}
 }
\ No newline at end of file
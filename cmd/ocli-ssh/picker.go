@@ -0,0 +1,366 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pickerAction is what happens to the bullet chosen in the picker.
+type pickerAction int
+
+const (
+	pickMoveTo pickerAction = iota
+	pickDuplicateTo
+	pickJump
+)
+
+// maxRecent is how many recently visited bullets the quick switcher favours.
+// The bonus is small, so it decides between similar matches only.
+const maxRecent = 20
+
+// picker is a fuzzy finder over the paths of bullets in the outline.
+type picker struct {
+	action       pickerAction
+	input        textinput.Model
+	subjects     []*Bullet // Bullets being moved or duplicated
+	candidates   []*Bullet // nil stands for the top level
+	matches      []*Bullet
+	index        int
+	asFirstChild bool
+	recency      map[*Bullet]int // Ranking bonus of recently visited bullets
+}
+
+// openRefile starts a move to… or duplicate to… picker for the selected
+// bullet or the visual selection. A bullet can't be moved into itself, so the
+// moved subtrees are left out of the targets.
+func (m *Model) openRefile(action pickerAction) tea.Cmd {
+	subjects := m.registerSource()
+	if len(subjects) == 0 {
+		return nil
+	}
+	if action == pickMoveTo && subjects[0] == m.zoomedBullet {
+		m.statusMessage = "Cannot move the zoomed bullet"
+		return nil
+	}
+
+	excluded := make(map[*Bullet]bool)
+	if action == pickMoveTo {
+		walkBullets(subjects, func(b *Bullet) {
+			excluded[b] = true
+		})
+	}
+	candidates := []*Bullet{nil}
+	walkBullets(m.rootBullets, func(b *Bullet) {
+		if !excluded[b] {
+			candidates = append(candidates, b)
+		}
+	})
+
+	m.picker = &picker{
+		action:     action,
+		input:      newPickerInput(),
+		subjects:   subjects,
+		candidates: candidates,
+	}
+	m.picker.filter()
+	m.stopVisual()
+	m.appMode = AppModePicker
+	return textinput.Blink
+}
+
+// openSwitcher starts the quick switcher over every bullet in the outline,
+// with recently visited ones ranked higher.
+func (m *Model) openSwitcher() tea.Cmd {
+	recency := make(map[*Bullet]int)
+	for i, id := range m.recent {
+		if b := findBulletByID(m.rootBullets, id); b != nil {
+			recency[b] = (maxRecent - i) / 4
+		}
+	}
+	var candidates []*Bullet
+	walkBullets(m.rootBullets, func(b *Bullet) {
+		candidates = append(candidates, b)
+	})
+
+	m.picker = &picker{
+		action:     pickJump,
+		input:      newPickerInput(),
+		candidates: candidates,
+		recency:    recency,
+	}
+	m.picker.filter()
+	m.stopVisual()
+	m.appMode = AppModePicker
+	return textinput.Blink
+}
+
+func newPickerInput() textinput.Model {
+	input := textinput.New()
+	input.Placeholder = "Type to filter"
+	input.Prompt = "> "
+	input.Focus()
+	return input
+}
+
+// visited records b as the most recently visited bullet.
+func (m *Model) visited(b *Bullet) {
+	recent := []string{b.ID}
+	for _, id := range m.recent {
+		if id != b.ID && len(recent) < maxRecent {
+			recent = append(recent, id)
+		}
+	}
+	m.recent = recent
+}
+
+// jumpTo zooms into b, or into its parent with b selected when b has no
+// children of its own.
+func (m *Model) jumpTo(b *Bullet) {
+	target := b
+	if len(b.Children) == 0 {
+		target = b.Parent
+	}
+	if target != nil {
+		target.Collapsed = false
+	}
+	m.pushJump()
+	m.zoomTo(target)
+	m.keepInFilter(b)
+	m.rebuildVisibleList()
+	m.selectBullet(b)
+	m.visited(b)
+}
+
+// filter ranks the candidates against the query, best match first.
+func (p *picker) filter() {
+	query := p.input.Value()
+	type scored struct {
+		bullet *Bullet
+		score  int
+	}
+	var ranked []scored
+	for _, b := range p.candidates {
+		if score, ok := fuzzyScore(query, pickerLabel(b)); ok {
+			ranked = append(ranked, scored{b, score + p.recency[b]})
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score > ranked[j].score
+	})
+
+	p.matches = p.matches[:0]
+	for _, r := range ranked {
+		p.matches = append(p.matches, r.bullet)
+	}
+	p.index = 0
+}
+
+// pickerLabel is the text a candidate is matched and shown by.
+func pickerLabel(b *Bullet) string {
+	if b == nil {
+		return "Top level"
+	}
+	return b.PathString()
+}
+
+// fuzzyScore reports whether the letters of query appear in order in text,
+// ignoring case, and how well: consecutive letters and letters at the start
+// of words score higher, long gaps lower.
+func fuzzyScore(query, text string) (int, bool) {
+	queryRunes := []rune(strings.ToLower(query))
+	if len(queryRunes) == 0 {
+		return 0, true
+	}
+
+	score := 0
+	matched := 0
+	lastMatch := -1
+	textRunes := []rune(text)
+	for i, r := range textRunes {
+		if matched == len(queryRunes) {
+			break
+		}
+		if unicode.ToLower(r) != queryRunes[matched] {
+			continue
+		}
+		switch {
+		case lastMatch == i-1:
+			score += 5
+		case lastMatch >= 0:
+			score -= i - lastMatch - 1
+		}
+		if i == 0 || !unicode.IsLetter(textRunes[i-1]) && !unicode.IsDigit(textRunes[i-1]) {
+			score += 3
+		}
+		lastMatch = i
+		matched++
+	}
+	return score, matched == len(queryRunes)
+}
+
+// refile moves or duplicates the picked subjects into target, which is nil
+// for the top level.
+func (m *Model) refile(target *Bullet) {
+	p := m.picker
+	for _, b := range p.subjects {
+		for ancestor := target; ancestor != nil; ancestor = ancestor.Parent {
+			if ancestor == b && p.action == pickMoveTo {
+				m.statusMessage = "Cannot move a bullet into itself"
+				return
+			}
+		}
+	}
+	m.recordUndo()
+
+	bullets := p.subjects
+	verb := "Moved"
+	if p.action == pickDuplicateTo {
+		bullets = copyTree(p.subjects)
+		renewIDs(bullets)
+		verb = "Duplicated"
+	} else {
+		for _, b := range bullets {
+			m.detach(b)
+		}
+	}
+
+	index := len(m.childrenOf(target))
+	if p.asFirstChild {
+		index = 0
+	}
+	m.insertAt(target, index, bullets)
+	for _, b := range bullets {
+		linkParents(b.Children, b)
+	}
+	if target != nil {
+		target.Collapsed = false
+	}
+
+	m.rebuildVisibleList()
+	if indexOf(m.allBullets, bullets[0]) >= 0 {
+		m.selectBullet(bullets[0])
+	} else {
+		m.clampSelection()
+	}
+	m.statusMessage = fmt.Sprintf("%s %d bullets to %s", verb, len(bullets), pickerLabel(target))
+
+	// Auto-save after refiling
+	m.saveData()
+}
+
+// updatePicker handles keys while the picker is open.
+func (m Model) updatePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.picker
+	switch msg.String() {
+	case "esc":
+		m.picker = nil
+		m.appMode = AppModeNormal
+		return m, nil
+
+	case "enter":
+		switch {
+		case p.index >= len(p.matches):
+		case p.action == pickJump:
+			m.jumpTo(p.matches[p.index])
+		default:
+			m.refile(p.matches[p.index])
+		}
+		m.picker = nil
+		m.appMode = AppModeNormal
+		return m, nil
+
+	case "up", "ctrl+k":
+		if p.index > 0 {
+			p.index--
+		}
+		return m, nil
+
+	case "down", "ctrl+j":
+		if p.index < len(p.matches)-1 {
+			p.index++
+		}
+		return m, nil
+
+	case "tab":
+		p.asFirstChild = !p.asFirstChild && p.action != pickJump
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	query := p.input.Value()
+	p.input, cmd = p.input.Update(msg)
+	if p.input.Value() != query {
+		p.filter()
+	}
+	return m, cmd
+}
+
+func (m Model) renderPicker(appStyle, titleStyle lipgloss.Style) string {
+	var contentBuilder strings.Builder
+	p := m.picker
+
+	title := "Move to…"
+	switch p.action {
+	case pickDuplicateTo:
+		title = "Duplicate to…"
+	case pickJump:
+		title = "Go to…"
+	}
+	contentBuilder.WriteString(titleStyle.Render(title))
+	contentBuilder.WriteString("\n\n")
+	contentBuilder.WriteString(p.input.View())
+	contentBuilder.WriteString("\n\n")
+
+	itemStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("255")).
+		Underline(true)
+
+	rows := m.height - 10
+	if rows < 1 {
+		rows = 1
+	}
+	start := 0
+	if p.index >= rows {
+		start = p.index - rows + 1
+	}
+	width := m.width - 4
+	for i := start; i < len(p.matches) && i < start+rows; i++ {
+		label := pickerLabel(p.matches[i])
+		if width > 1 {
+			label = truncate(label, width)
+		}
+		if i == p.index {
+			contentBuilder.WriteString(selectedStyle.Render(label))
+		} else {
+			contentBuilder.WriteString(itemStyle.Render(label))
+		}
+		contentBuilder.WriteString("\n")
+	}
+	if len(p.matches) == 0 {
+		contentBuilder.WriteString(itemStyle.Faint(true).Render("No matches"))
+		contentBuilder.WriteString("\n")
+	}
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		MarginTop(2)
+
+	position := "last child"
+	if p.asFirstChild {
+		position = "first child"
+	}
+	help := fmt.Sprintf("\nAs %s • Tab:first/last child • ↑↓:choose • Enter:confirm • Esc:cancel", position)
+	if p.action == pickJump {
+		help = "\n↑↓:choose • Enter:go there • Esc:cancel"
+	}
+	contentBuilder.WriteString(helpStyle.Render(help))
+
+	return appStyle.Render(contentBuilder.String())
+}
@@ -0,0 +1,128 @@
+package main
+
+import "fmt"
+
+// The register holds copies of subtrees for putting elsewhere, vim style.
+// It lives for the session, so it works across zoom levels. A cut keeps the
+// original IDs for its first put, making cut-and-put a move; every other put
+// gets fresh IDs so copies never collide with their originals. There is only
+// one outline per data file, so the register is not shared across outlines.
+
+// yank copies the selected bullet, or the visual selection, into the register.
+func (m *Model) yank() {
+	bullets := m.registerSource()
+	if len(bullets) == 0 {
+		return
+	}
+	m.register = copyTree(bullets)
+	m.registerIsCut = false
+	m.stopVisual()
+	m.statusMessage = fmt.Sprintf("Yanked %s", m.registerSummary())
+}
+
+// cut moves the selected bullet, or the visual selection, into the register
+// and removes it from the outline. The register is the only copy, so nothing
+// goes to the trash.
+func (m *Model) cut() {
+	bullets := m.registerSource()
+	if len(bullets) == 0 {
+		return
+	}
+	if bullets[0] == m.zoomedBullet {
+		m.statusMessage = "Cannot cut the zoomed bullet"
+		return
+	}
+	first := m.selectedIndex
+	if m.visualAnchor != nil {
+		first, _, _ = m.visualRange()
+	}
+	m.recordUndo()
+
+	m.register = copyTree(bullets)
+	m.registerIsCut = true
+	for _, b := range bullets {
+		m.detach(b)
+		b.Parent = nil
+	}
+	m.stopVisual()
+
+	m.rebuildVisibleList()
+	m.selectedIndex = first
+	m.clampSelection()
+	m.statusMessage = fmt.Sprintf("Cut %s", m.registerSummary())
+
+	// Auto-save after cutting bullets
+	m.saveData()
+}
+
+// registerSource returns the subtrees yank and cut act on. The zoomed bullet
+// can be yanked but not cut, since the view is built on it.
+func (m *Model) registerSource() []*Bullet {
+	if m.visualAnchor != nil {
+		return m.rangeRoots()
+	}
+	selected := m.getSelectedBullet()
+	if selected == nil {
+		return nil
+	}
+	return []*Bullet{selected}
+}
+
+// put inserts the register after the selected bullet, or as its last child.
+// Putting next to the zoomed bullet puts into it instead.
+func (m *Model) put(asChild bool) {
+	if len(m.register) == 0 {
+		m.statusMessage = "Register is empty"
+		return
+	}
+
+	selected := m.getSelectedBullet()
+	var parent *Bullet
+	index := len(m.rootBullets)
+	switch {
+	case selected == nil && m.zoomedBullet != nil:
+		parent = m.zoomedBullet
+		index = len(parent.Children)
+	case selected == nil:
+	case asChild || selected == m.zoomedBullet:
+		parent = selected
+		index = len(selected.Children)
+	default:
+		parent = selected.Parent
+		index = indexOf(m.siblingsOf(selected), selected) + 1
+	}
+	m.recordUndo()
+
+	bullets := copyTree(m.register)
+	if !m.registerIsCut || m.inOutline(m.register) {
+		renewIDs(bullets)
+	}
+	m.registerIsCut = false
+	linkParents(bullets, parent)
+	m.insertAt(parent, index, bullets)
+	m.keepInFilter(bullets...)
+	if parent != nil {
+		parent.Collapsed = false
+	}
+
+	m.rebuildVisibleList()
+	m.selectBullet(bullets[0])
+	m.statusMessage = fmt.Sprintf("Put %s", m.registerSummary())
+
+	// Auto-save after putting bullets
+	m.saveData()
+}
+
+// registerSummary describes the register contents for the status line.
+func (m Model) registerSummary() string {
+	if len(m.register) == 0 {
+		return ""
+	}
+	count := 0
+	walkBullets(m.register, func(*Bullet) { count++ })
+	summary := fmt.Sprintf("%q", truncate(m.register[0].Content, 24))
+	if count > 1 {
+		summary += fmt.Sprintf(" +%d", count-1)
+	}
+	return summary
+}
@@ -23,9 +23,10 @@ type Server struct {
 	dataDir      string
 	authManager  *AuthManager
 	autoRegister bool
+	readOnly     bool
 }
 
-func NewServer(host, port, dataDir, keyPath string, autoRegister bool) (*Server, error) {
+func NewServer(host, port, dataDir, keyPath string, autoRegister, readOnly bool) (*Server, error) {
 	// Create auth manager
 	authManager, err := NewAuthManager(dataDir)
 	if err != nil {
@@ -39,6 +40,7 @@ func NewServer(host, port, dataDir, keyPath string, autoRegister bool) (*Server,
 		dataDir:      dataDir,
 		authManager:  authManager,
 		autoRegister: autoRegister,
+		readOnly:     readOnly,
 	}
 
 	// Set up middleware
@@ -133,7 +135,7 @@ func (s *Server) teaHandler(sess ssh.Session) (tea.Model, []tea.ProgramOption) {
 	os.Setenv("FORCE_COLOR", "1")
 
 	// Create user-specific model
-	model, err := NewSSHModel(username, s.dataDir)
+	model, err := NewSSHModel(username, s.dataDir, s.readOnly)
 	if err != nil {
 		// Return error model
 		return NewErrorModel(fmt.Sprintf("Failed to initialize: %v", err)), []tea.ProgramOption{tea.WithAltScreen()}
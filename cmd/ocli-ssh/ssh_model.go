@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -17,8 +18,9 @@ type SSHModel struct {
 	configManager *SSHConfigManager
 }
 
-// NewSSHModel creates a new model for SSH sessions
-func NewSSHModel(username, dataDir string) (*SSHModel, error) {
+// NewSSHModel creates a new model for SSH sessions. The session is read-only
+// when readOnly is set or the user's data file is marked read-only.
+func NewSSHModel(username, dataDir string, readOnly bool) (*SSHModel, error) {
 	// Create user-specific directory
 	userDir := filepath.Join(dataDir, "users", username)
 	if err := os.MkdirAll(userDir, 0700); err != nil {
@@ -45,8 +47,14 @@ func NewSSHModel(username, dataDir string) (*SSHModel, error) {
 	// Override with user-specific data
 	baseModel.rootBullets = data.RootBullets
 	baseModel.settings = data.Settings
+	baseModel.readOnly = readOnly || data.ReadOnly
+	baseModel.trash = data.Trash
+	baseModel.archive = data.Archive
+	baseModel.bookmarks = data.Bookmarks
+	baseModel.purgeTrash(time.Now())
 	// Note: baseModel.configManager stays as the original since types don't match
 	baseModel.rebuildVisibleList()
+	baseModel.restoreView(data.View)
 
 	return &SSHModel{
 		Model:         baseModel,
@@ -69,6 +77,11 @@ func (cm *SSHConfigManager) Save(data *AppData) error {
 	cleanData := &AppData{
 		RootBullets: copyBulletsWithoutParents(data.RootBullets),
 		Settings:    data.Settings,
+		ReadOnly:    data.ReadOnly,
+		Trash:       copyRemoved(data.Trash),
+		Archive:     copyRemoved(data.Archive),
+		Bookmarks:   data.Bookmarks,
+		View:        data.View,
 	}
 
 	jsonData, err := json.MarshalIndent(cleanData, "", "  ")
@@ -109,6 +122,15 @@ func (cm *SSHConfigManager) createDefaultData() *AppData {
 
 // Update overrides the base model's Update to handle SSH-specific saving
 func (m *SSHModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// An external editor would run on the server, not in the user's terminal
+	if key, ok := msg.(tea.KeyMsg); ok && key.String() == "E" && m.editMode == EditModeNone && m.appMode == AppModeNormal {
+		m.statusMessage = "Editing in $EDITOR is not available over SSH"
+		return m, nil
+	}
+
+	// A join switches editing to the previous bullet
+	editing := m.editingBullet
+
 	// Call the base model's update
 	updatedModel, cmd := m.Model.Update(msg)
 	
@@ -118,14 +140,22 @@ func (m *SSHModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Save data after any operation that might change it
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "enter", "d", "tab", "shift+tab", "shift+up", "shift+down", "c", "t", "x", "e":
+		switch key := msg.String(); {
+		case mutatingKeys[key]:
 			// These operations modify data, so save
 			m.saveSSHData()
-		case "q", "ctrl+c":
+		case key == "backspace" && editing != nil && m.editingBullet != editing:
+			// Backspace at the start of a bullet joined it into the previous one
+			m.saveSSHData()
+		case key == "q", key == "ctrl+c":
 			// Save before quitting
 			m.saveSSHData()
 		}
+	case tea.MouseMsg:
+		if msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress {
+			// Clicks can complete tasks and fold branches
+			m.saveSSHData()
+		}
 	}
 	
 	return m, cmd
@@ -133,9 +163,16 @@ func (m *SSHModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // saveSSHData saves the current state using SSH config manager
 func (m *SSHModel) saveSSHData() error {
+	if m.readOnly {
+		return nil
+	}
 	data := &AppData{
 		RootBullets: m.rootBullets,
 		Settings:    m.settings,
+		Trash:       m.trash,
+		Archive:     m.archive,
+		Bookmarks:   m.bookmarks,
+		View:        m.viewState(),
 	}
 	return m.configManager.Save(data)
 }
@@ -180,6 +217,7 @@ func getDefaultSSHData(username string) *AppData {
 		RootBullets: []*Bullet{welcome},
 		Settings: Settings{
 			ShowHierarchyLines: true,
+			TrashRetentionDays: defaultTrashRetentionDays,
 		},
 	}
 }
@@ -194,6 +232,7 @@ func copyBulletsWithoutParents(bullets []*Bullet) []*Bullet {
 		result[i] = &Bullet{
 			ID:        b.ID,
 			Content:   b.Content,
+			Note:      b.Note,
 			Children:  copyBulletsWithoutParents(b.Children),
 			Collapsed: b.Collapsed,
 			IsTask:    b.IsTask,
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+const largestSubtreeCount = 5
+
+// OutlineStats summarises a tree of bullets.
+type OutlineStats struct {
+	Scope             string         `json:"scope"`
+	TotalBullets      int            `json:"totalBullets"`
+	DepthDistribution []int          `json:"depthDistribution"`
+	OpenTasks         int            `json:"openTasks"`
+	DoneTasks         int            `json:"doneTasks"`
+	Colors            map[string]int `json:"colors"`
+	Branches          []BranchStats  `json:"branches"`
+	LargestSubtrees   []BranchStats  `json:"largestSubtrees"`
+}
+
+// BranchStats counts the bullets and tasks below a single bullet.
+type BranchStats struct {
+	ID        string `json:"id"`
+	Path      string `json:"path"`
+	Bullets   int    `json:"bullets"`
+	OpenTasks int    `json:"openTasks"`
+	DoneTasks int    `json:"doneTasks"`
+}
+
+// computeStats gathers statistics for the given top-level branches. When
+// scope is non-nil the branches are its children and paths are relative to it.
+func computeStats(branches []*Bullet, scope *Bullet) OutlineStats {
+	stats := OutlineStats{
+		Colors:            make(map[string]int),
+		DepthDistribution: make([]int, 0),
+		Branches:          make([]BranchStats, 0),
+		LargestSubtrees:   make([]BranchStats, 0),
+	}
+	if scope != nil {
+		stats.Scope = scope.PathString()
+	}
+
+	var subtrees []BranchStats
+	var visit func(b *Bullet, depth int) BranchStats
+	visit = func(b *Bullet, depth int) BranchStats {
+		stats.TotalBullets++
+		for len(stats.DepthDistribution) <= depth {
+			stats.DepthDistribution = append(stats.DepthDistribution, 0)
+		}
+		stats.DepthDistribution[depth]++
+		stats.Colors[b.Color.String()]++
+
+		branch := BranchStats{ID: b.ID, Path: relativePath(b, scope), Bullets: 1}
+		if b.IsTask {
+			if b.Completed {
+				branch.DoneTasks++
+				stats.DoneTasks++
+			} else {
+				branch.OpenTasks++
+				stats.OpenTasks++
+			}
+		}
+		for _, child := range b.Children {
+			childStats := visit(child, depth+1)
+			branch.Bullets += childStats.Bullets
+			branch.OpenTasks += childStats.OpenTasks
+			branch.DoneTasks += childStats.DoneTasks
+		}
+		if len(b.Children) > 0 {
+			subtrees = append(subtrees, branch)
+		}
+		return branch
+	}
+
+	for _, b := range branches {
+		stats.Branches = append(stats.Branches, visit(b, 0))
+	}
+
+	sort.SliceStable(subtrees, func(i, j int) bool {
+		return subtrees[i].Bullets > subtrees[j].Bullets
+	})
+	if len(subtrees) > largestSubtreeCount {
+		subtrees = subtrees[:largestSubtreeCount]
+	}
+	stats.LargestSubtrees = append(stats.LargestSubtrees, subtrees...)
+
+	return stats
+}
+
+// relativePath renders b's path without the ancestors above scope.
+func relativePath(b *Bullet, scope *Bullet) string {
+	var parts []string
+	for _, p := range b.GetPath() {
+		if scope != nil && p == scope {
+			parts = nil
+			continue
+		}
+		parts = append(parts, p.Content)
+	}
+	return strings.Join(parts, " > ")
+}
+
+type statsSection struct {
+	title string
+	items []string
+}
+
+// sections lays the statistics out for both the CLI and the stats screen.
+func (s OutlineStats) sections() []statsSection {
+	overview := []string{
+		fmt.Sprintf("Bullets      %d", s.TotalBullets),
+		fmt.Sprintf("Open tasks   %d", s.OpenTasks),
+		fmt.Sprintf("Done tasks   %d", s.DoneTasks),
+	}
+	if s.Scope != "" {
+		overview = append([]string{"Scope        " + s.Scope}, overview...)
+	}
+
+	maxDepthCount := 0
+	for _, count := range s.DepthDistribution {
+		if count > maxDepthCount {
+			maxDepthCount = count
+		}
+	}
+	var depths []string
+	for depth, count := range s.DepthDistribution {
+		bar := strings.Repeat("█", (count*30+maxDepthCount-1)/maxDepthCount)
+		depths = append(depths, fmt.Sprintf("Level %-3d %5d %s", depth+1, count, bar))
+	}
+
+	var branches []string
+	for _, branch := range s.Branches {
+		branches = append(branches, fmt.Sprintf("%-30s %4d bullets, %d open, %d done",
+			truncate(branch.Path, 30), branch.Bullets, branch.OpenTasks, branch.DoneTasks))
+	}
+
+	var colors []string
+	for i := range colorNames {
+		name := BulletColor(i).String()
+		if count := s.Colors[name]; count > 0 {
+			colors = append(colors, fmt.Sprintf("%-8s %d", name, count))
+		}
+	}
+
+	var largest []string
+	for _, subtree := range s.LargestSubtrees {
+		largest = append(largest, fmt.Sprintf("%4d  %s", subtree.Bullets, subtree.Path))
+	}
+
+	return []statsSection{
+		{"Overview", overview},
+		{"Depth", depths},
+		{"Branches", branches},
+		{"Colors", colors},
+		{"Largest subtrees", largest},
+	}
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// runStats implements `ocli stats [--json] [--id ID]`.
+func runStats(args []string) error {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "Print statistics as JSON")
+	id := flags.String("id", "", "Only include the subtree of this bullet (ID or prefix)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	configManager, err := NewConfigManager()
+	if err != nil {
+		return err
+	}
+	data, err := configManager.Load()
+	if err != nil {
+		return err
+	}
+
+	branches := data.RootBullets
+	var scope *Bullet
+	if *id != "" {
+		if scope, err = resolveBulletID(data.RootBullets, *id); err != nil {
+			return err
+		}
+		branches = scope.Children
+	}
+	stats := computeStats(branches, scope)
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	}
+
+	for _, section := range stats.sections() {
+		fmt.Println(section.title)
+		for _, item := range section.items {
+			fmt.Println("  " + item)
+		}
+		fmt.Println()
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// stickyAncestors returns the ancestors of the bullet at index top that are
+// scrolled out of view, outermost first. They are pinned above the list so
+// it stays clear which branch the screen is showing. In very deep branches
+// only the innermost ones are pinned, leaving at least one line for the list.
+func (m Model) stickyAncestors(top int) []*Bullet {
+	if top <= 0 || top >= len(m.allBullets) {
+		return nil
+	}
+	var chain []*Bullet
+	for a := m.allBullets[top].Parent; a != nil; a = a.Parent {
+		if i := indexOf(m.allBullets, a); i >= 0 && i < top {
+			chain = append([]*Bullet{a}, chain...)
+		}
+	}
+	limit := m.contentHeight() - 1
+	if limit < 0 {
+		limit = 0
+	}
+	if len(chain) > limit {
+		chain = chain[len(chain)-limit:]
+	}
+	return chain
+}
+
+// listHeight is how many lines of bullets fit below the pinned ancestors.
+func (m Model) listHeight() int {
+	return m.contentHeight() - len(m.stickyAncestors(m.scrollOffset))
+}
+
+// fitsOnScreen reports whether the bullets from first to last all fit when
+// first is the top of the list.
+func (m Model) fitsOnScreen(first, last int) bool {
+	lines := 0
+	for i := first; i <= last && i < len(m.allBullets); i++ {
+		lines += len(m.renderBullet(i))
+	}
+	return lines <= m.contentHeight()-len(m.stickyAncestors(first))
+}
+
+// renderSticky renders the pinned ancestors, one dimmed line each.
+func (m Model) renderSticky() []string {
+	stickyStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("244")).
+		Faint(true)
+
+	var lines []string
+	for _, a := range m.stickyAncestors(m.scrollOffset) {
+		depth := m.bulletDepth(a)
+		prefix := bulletPrefix(a)
+		content := a.Content
+		if width := m.contentWidth(depth, lipgloss.Width(prefix)); width > 0 {
+			content = truncate(content, width)
+		}
+		lines = append(lines, stickyStyle.Render(strings.Repeat("    ", depth)+prefix+content))
+	}
+	return lines
+}
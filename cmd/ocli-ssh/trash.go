@@ -0,0 +1,342 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// defaultTrashRetentionDays applies to new outlines. Older data files have
+// no retention setting, which keeps their trash forever.
+const defaultTrashRetentionDays = 30
+
+// trashRetentionChoices are the values the settings screen cycles through;
+// 0 keeps deleted bullets forever.
+var trashRetentionChoices = []int{7, 30, 90, 0}
+
+// RemovedBullet is a branch taken out of the outline, either deleted into the
+// trash or archived, with its old place recorded so it can be put back.
+type RemovedBullet struct {
+	Bullet    *Bullet   `json:"bullet"`
+	ParentID  string    `json:"parentId,omitempty"`
+	Index     int       `json:"index"`
+	Path      string    `json:"path,omitempty"` // Where it was, for display
+	RemovedAt time.Time `json:"removedAt"`
+}
+
+// copyRemoved deep-copies entries without parent references, for saving and
+// for undo snapshots.
+func copyRemoved(entries []*RemovedBullet) []*RemovedBullet {
+	if entries == nil {
+		return nil
+	}
+	copies := make([]*RemovedBullet, len(entries))
+	for i, entry := range entries {
+		entryCopy := *entry
+		entryCopy.Bullet = copyTree([]*Bullet{entry.Bullet})[0]
+		copies[i] = &entryCopy
+	}
+	return copies
+}
+
+// removeBullets takes the given branches out of the tree and records them,
+// newest first, in the trash or archive list.
+func (m *Model) removeBullets(bullets []*Bullet, into *[]*RemovedBullet) {
+	now := time.Now()
+	var entries []*RemovedBullet
+	for _, b := range bullets {
+		entry := &RemovedBullet{
+			Index:     indexOf(m.siblingsOf(b), b),
+			RemovedAt: now,
+		}
+		if b.Parent != nil {
+			entry.ParentID = b.Parent.ID
+			entry.Path = b.Parent.PathString()
+		}
+		m.detach(b)
+		b.Parent = nil
+		entry.Bullet = b
+		entries = append(entries, entry)
+	}
+	*into = append(entries, *into...)
+}
+
+// openTasks reports whether any bullet in the branches is an unfinished task.
+func openTasks(bullets []*Bullet) bool {
+	open := false
+	walkBullets(bullets, func(b *Bullet) {
+		open = open || (b.IsTask && !b.Completed)
+	})
+	return open
+}
+
+// archiveBullets moves finished branches out of the tree into the archive,
+// where they remain searchable. Branches with open tasks are refused.
+func (m *Model) archiveBullets(bullets []*Bullet) {
+	if len(bullets) == 0 {
+		return
+	}
+	for _, b := range bullets {
+		if b == m.zoomedBullet {
+			m.statusMessage = "Cannot archive the zoomed bullet"
+			return
+		}
+	}
+	if openTasks(bullets) {
+		m.statusMessage = "Only branches without open tasks can be archived"
+		return
+	}
+	first := indexOf(m.allBullets, bullets[0])
+	m.recordUndo()
+
+	m.removeBullets(bullets, &m.archive)
+	m.stopVisual()
+	m.rebuildVisibleList()
+	m.selectedIndex = first
+	m.clampSelection()
+	m.statusMessage = fmt.Sprintf("Archived %d bullets (T to view)", len(bullets))
+
+	// Auto-save after archiving
+	m.saveData()
+}
+
+// clampSelection keeps the selection inside the visible list after bullets
+// have been removed from it.
+func (m *Model) clampSelection() {
+	if m.selectedIndex >= len(m.allBullets) {
+		m.selectedIndex = len(m.allBullets) - 1
+	}
+	if m.selectedIndex < 0 {
+		m.selectedIndex = 0
+	}
+	m.ensureSelectedVisible()
+}
+
+// purgeTrash permanently drops trash entries older than the retention setting.
+func (m *Model) purgeTrash(now time.Time) {
+	days := m.settings.TrashRetentionDays
+	if days <= 0 {
+		return
+	}
+	cutoff := now.AddDate(0, 0, -days)
+	var kept []*RemovedBullet
+	for _, entry := range m.trash {
+		if entry.RemovedAt.After(cutoff) {
+			kept = append(kept, entry)
+		}
+	}
+	m.trash = kept
+}
+
+// removedList returns the list shown in the trash view.
+func (m *Model) removedList() *[]*RemovedBullet {
+	if m.showArchive {
+		return &m.archive
+	}
+	return &m.trash
+}
+
+// restoreRemoved puts an entry back at its recorded place, or at the end of
+// the top level when its parent no longer exists.
+func (m *Model) restoreRemoved(index int) {
+	list := m.removedList()
+	entry := (*list)[index]
+	m.recordUndo()
+
+	*list = append((*list)[:index:index], (*list)[index+1:]...)
+
+	parent := findBulletByID(m.rootBullets, entry.ParentID)
+	position := entry.Index
+	if parent == nil && entry.ParentID != "" {
+		position = len(m.rootBullets)
+	}
+	if position < 0 || position > len(m.childrenOf(parent)) {
+		position = len(m.childrenOf(parent))
+	}
+
+	b := entry.Bullet
+	if m.inOutline([]*Bullet{b}) {
+		// A copy of it was put back some other way meanwhile
+		renewIDs([]*Bullet{b})
+	}
+	m.insertAt(parent, position, []*Bullet{b})
+	linkParents(b.Children, b)
+	if parent != nil {
+		parent.Collapsed = false
+	}
+
+	m.rebuildVisibleList()
+	m.selectBullet(b)
+	if parent == nil && entry.ParentID != "" {
+		m.statusMessage = fmt.Sprintf("Restored %q at the top level, its parent is gone", truncate(b.Content, 30))
+	} else {
+		m.statusMessage = fmt.Sprintf("Restored %q", truncate(b.Content, 30))
+	}
+
+	// Auto-save after restoring
+	m.saveData()
+}
+
+// inOutline reports whether any bullet of the given trees is in the outline.
+func (m *Model) inOutline(bullets []*Bullet) bool {
+	found := false
+	walkBullets(bullets, func(b *Bullet) {
+		found = found || findBulletByID(m.rootBullets, b.ID) != nil
+	})
+	return found
+}
+
+// updateTrash handles keys in the trash and archive view.
+func (m Model) updateTrash(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	list := *m.removedList()
+	switch msg.String() {
+	case "q", "esc", "T":
+		m.appMode = AppModeNormal
+		m.ensureSelectedVisible()
+
+	case "tab":
+		m.showArchive = !m.showArchive
+		m.trashIndex = 0
+
+	case "up", "k":
+		if m.trashIndex > 0 {
+			m.trashIndex--
+		}
+
+	case "down", "j":
+		if m.trashIndex < len(list)-1 {
+			m.trashIndex++
+		}
+
+	case "enter", "d":
+		if m.trashIndex >= len(list) {
+			return m, nil
+		}
+		if m.readOnly {
+			m.statusMessage = "Read-only: changes are disabled"
+			return m, nil
+		}
+		if msg.String() == "enter" {
+			m.restoreRemoved(m.trashIndex)
+		} else {
+			m.recordUndo()
+			removed := m.removedList()
+			*removed = append(list[:m.trashIndex:m.trashIndex], list[m.trashIndex+1:]...)
+			m.statusMessage = "Deleted permanently"
+			// Auto-save after deleting permanently
+			m.saveData()
+		}
+		if m.trashIndex >= len(*m.removedList()) && m.trashIndex > 0 {
+			m.trashIndex--
+		}
+	}
+	return m, nil
+}
+
+func (m Model) renderTrash(appStyle, titleStyle lipgloss.Style) string {
+	var contentBuilder strings.Builder
+
+	title := "Trash"
+	if m.showArchive {
+		title = "Archive"
+	}
+	contentBuilder.WriteString(titleStyle.Render(title))
+	contentBuilder.WriteString("\n\n")
+
+	itemStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("255")).
+		Underline(true)
+	detailStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	list := *m.removedList()
+	if len(list) == 0 {
+		contentBuilder.WriteString(detailStyle.Render(fmt.Sprintf("The %s is empty", strings.ToLower(title))))
+		contentBuilder.WriteString("\n")
+	}
+
+	// Two lines per entry; keep the selected entry in view
+	rows := (m.height - 8) / 2
+	if rows < 1 {
+		rows = 1
+	}
+	start := 0
+	if m.trashIndex >= rows {
+		start = m.trashIndex - rows + 1
+	}
+	for i := start; i < len(list) && i < start+rows; i++ {
+		entry := list[i]
+		count := 0
+		walkBullets([]*Bullet{entry.Bullet}, func(*Bullet) { count++ })
+
+		line := entry.Bullet.Content
+		if count > 1 {
+			line += fmt.Sprintf(" (+%d)", count-1)
+		}
+		if i == m.trashIndex {
+			contentBuilder.WriteString(selectedStyle.Render(line))
+		} else {
+			contentBuilder.WriteString(itemStyle.Render(line))
+		}
+		contentBuilder.WriteString("\n")
+
+		from := entry.Path
+		if from == "" {
+			from = "top level"
+		}
+		contentBuilder.WriteString(detailStyle.Render(fmt.Sprintf("  from %s • %s", from, removedAge(entry.RemovedAt, time.Now()))))
+		contentBuilder.WriteString("\n")
+	}
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		MarginTop(2)
+
+	other := "archive"
+	if m.showArchive {
+		other = "trash"
+	}
+	help := fmt.Sprintf("\nKeys: ↑↓/jk:navigate • Enter:restore • d:delete forever • Tab:%s • T/esc/q:back", other)
+	if m.statusMessage != "" {
+		help = "\n" + m.statusMessage
+	}
+	contentBuilder.WriteString(helpStyle.Render(help))
+
+	return appStyle.Render(contentBuilder.String())
+}
+
+// removedAge describes how long ago something was removed.
+func removedAge(removedAt, now time.Time) string {
+	age := now.Sub(removedAt)
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(age.Hours()/24))
+	}
+}
+
+// retentionLabel describes a trash retention setting.
+func retentionLabel(days int) string {
+	if days <= 0 {
+		return "never"
+	}
+	return fmt.Sprintf("%d days", days)
+}
+
+// nextRetention returns the retention choice after days.
+func nextRetention(days int) int {
+	for i, choice := range trashRetentionChoices {
+		if choice == days {
+			return trashRetentionChoices[(i+1)%len(trashRetentionChoices)]
+		}
+	}
+	return trashRetentionChoices[0]
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+const (
+	maxUndoHistory       = 100
+	persistedUndoHistory = 20
+)
+
+// undoState is a snapshot of the outline together with the selection and
+// zoom at the time it was taken, so undo can put the user back exactly.
+type undoState struct {
+	RootBullets []*Bullet        `json:"rootBullets"`
+	Trash       []*RemovedBullet `json:"trash,omitempty"`
+	Archive     []*RemovedBullet `json:"archive,omitempty"`
+	Bookmarks   []string         `json:"bookmarks,omitempty"`
+	SelectedID  string           `json:"selectedId,omitempty"`
+	ZoomedID    string           `json:"zoomedId,omitempty"`
+}
+
+// undoHistory is the on-disk form of the undo stack. It is only reused when
+// the outline still matches the fingerprint it was saved with.
+type undoHistory struct {
+	Fingerprint string      `json:"fingerprint"`
+	Undo        []undoState `json:"undo"`
+}
+
+// snapshot captures the current outline and view position.
+func (m *Model) snapshot() undoState {
+	state := undoState{
+		RootBullets: copyTree(m.rootBullets),
+		Trash:       copyRemoved(m.trash),
+		Archive:     copyRemoved(m.archive),
+		Bookmarks:   append([]string(nil), m.bookmarks...),
+	}
+	if selected := m.getSelectedBullet(); selected != nil {
+		state.SelectedID = selected.ID
+	}
+	if m.zoomedBullet != nil {
+		state.ZoomedID = m.zoomedBullet.ID
+	}
+	return state
+}
+
+// recordUndo must be called right before every change to the outline.
+func (m *Model) recordUndo() {
+	m.undoStack = append(m.undoStack, m.snapshot())
+	if len(m.undoStack) > maxUndoHistory {
+		m.undoStack = m.undoStack[len(m.undoStack)-maxUndoHistory:]
+	}
+	m.redoStack = nil
+}
+
+func (m *Model) undo() {
+	if m.stepHistory(&m.undoStack, &m.redoStack) {
+		m.statusMessage = "Undone"
+	} else {
+		m.statusMessage = "Nothing to undo"
+	}
+}
+
+func (m *Model) redo() {
+	if m.stepHistory(&m.redoStack, &m.undoStack) {
+		m.statusMessage = "Redone"
+	} else {
+		m.statusMessage = "Nothing to redo"
+	}
+}
+
+// stepHistory restores the newest state from one stack, pushing the current
+// state onto the other. Entries identical to the current outline, trash and
+// bookmarks (recorded for operations that turned out to be no-ops) are skipped.
+func (m *Model) stepHistory(from, to *[]undoState) bool {
+	current := m.snapshot()
+	currentFingerprint := current.fingerprint()
+
+	for len(*from) > 0 {
+		state := (*from)[len(*from)-1]
+		*from = (*from)[:len(*from)-1]
+		if state.fingerprint() == currentFingerprint {
+			continue
+		}
+
+		*to = append(*to, current)
+		m.restoreState(state)
+		m.saveData()
+		return true
+	}
+	return false
+}
+
+// restoreState replaces the outline with a snapshot and restores the zoom
+// and selection it recorded.
+func (m *Model) restoreState(state undoState) {
+	m.rootBullets = copyTree(state.RootBullets)
+	linkParents(m.rootBullets, nil)
+	m.trash = copyRemoved(state.Trash)
+	m.archive = copyRemoved(state.Archive)
+	m.bookmarks = append([]string(nil), state.Bookmarks...)
+
+	m.zoomTo(findBulletByID(m.rootBullets, state.ZoomedID))
+	if selected := findBulletByID(m.rootBullets, state.SelectedID); selected != nil {
+		m.selectBullet(selected)
+	}
+}
+
+// treeFingerprint identifies the content and shape of a tree.
+func treeFingerprint(bullets []*Bullet) string {
+	jsonBytes, _ := json.Marshal(copyTree(bullets))
+	sum := sha256.Sum256(jsonBytes)
+	return hex.EncodeToString(sum[:])
+}
+
+// fingerprint identifies the outline, trash, archive and bookmarks of a state.
+func (s undoState) fingerprint() string {
+	jsonBytes, _ := json.Marshal(undoState{
+		RootBullets: copyTree(s.RootBullets),
+		Trash:       copyRemoved(s.Trash),
+		Archive:     copyRemoved(s.Archive),
+		Bookmarks:   s.Bookmarks,
+	})
+	sum := sha256.Sum256(jsonBytes)
+	return hex.EncodeToString(sum[:])
+}
+
+// history returns the most recent undo states for persisting.
+func (m *Model) history() *undoHistory {
+	states := m.undoStack
+	if len(states) > persistedUndoHistory {
+		states = states[len(states)-persistedUndoHistory:]
+	}
+	return &undoHistory{
+		Fingerprint: treeFingerprint(m.rootBullets),
+		Undo:        states,
+	}
+}
+
+// restoreHistory reloads a persisted undo stack if it belongs to the
+// outline that was just loaded.
+func (m *Model) restoreHistory(history *undoHistory) {
+	if history != nil && history.Fingerprint == treeFingerprint(m.rootBullets) {
+		m.undoStack = history.Undo
+	}
+}
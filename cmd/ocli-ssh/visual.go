@@ -0,0 +1,391 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Visual mode selects a contiguous run of visible bullets between an anchor
+// and the cursor. The anchor is kept as a bullet rather than an index so the
+// range survives the visible list being rebuilt by bulk operations.
+
+// startVisual anchors a range selection at the selected bullet.
+func (m *Model) startVisual() {
+	m.visualAnchor = m.getSelectedBullet()
+}
+
+func (m *Model) stopVisual() {
+	m.visualAnchor = nil
+}
+
+// visualRange returns the first and last visible indices of the selection.
+// ok is false when visual mode is off or the anchor is no longer visible.
+func (m Model) visualRange() (first, last int, ok bool) {
+	if m.visualAnchor == nil {
+		return 0, 0, false
+	}
+	for i, b := range m.allBullets {
+		if b == m.visualAnchor {
+			if i < m.selectedIndex {
+				return i, m.selectedIndex, true
+			}
+			return m.selectedIndex, i, true
+		}
+	}
+	return 0, 0, false
+}
+
+// inVisualRange reports whether the visible bullet at index i is selected.
+func (m Model) inVisualRange(i int) bool {
+	first, last, ok := m.visualRange()
+	return ok && i >= first && i <= last
+}
+
+// rangeBullets returns the selected bullets in visible order.
+func (m Model) rangeBullets() []*Bullet {
+	first, last, ok := m.visualRange()
+	if !ok {
+		return nil
+	}
+	return append([]*Bullet{}, m.allBullets[first:last+1]...)
+}
+
+// rangeRoots returns the selected bullets that have no selected ancestor, in
+// order. Moving these carries the rest of the selection along with them.
+// The zoomed bullet is never moved, so its children count as roots.
+func (m Model) rangeRoots() []*Bullet {
+	selected := make(map[*Bullet]bool)
+	for _, b := range m.rangeBullets() {
+		if b != m.zoomedBullet {
+			selected[b] = true
+		}
+	}
+
+	var roots []*Bullet
+	for _, b := range m.rangeBullets() {
+		if !selected[b] {
+			continue
+		}
+		root := true
+		for ancestor := b.Parent; ancestor != nil; ancestor = ancestor.Parent {
+			if selected[ancestor] {
+				root = false
+				break
+			}
+		}
+		if root {
+			roots = append(roots, b)
+		}
+	}
+	return roots
+}
+
+// siblingsOf returns the list b belongs to: its parent's children or the
+// root bullets.
+func (m *Model) siblingsOf(b *Bullet) []*Bullet {
+	return m.childrenOf(b.Parent)
+}
+
+// childrenOf returns parent's children, or the roots when parent is nil.
+func (m *Model) childrenOf(parent *Bullet) []*Bullet {
+	if parent == nil {
+		return m.rootBullets
+	}
+	return parent.Children
+}
+
+// setSiblings replaces the children of parent, or the root bullets when
+// parent is nil.
+func (m *Model) setSiblings(parent *Bullet, siblings []*Bullet) {
+	for _, b := range siblings {
+		b.Parent = parent
+	}
+	if parent == nil {
+		m.rootBullets = siblings
+	} else {
+		parent.Children = siblings
+	}
+}
+
+// detach removes b from its sibling list, leaving b.Parent untouched.
+func (m *Model) detach(b *Bullet) {
+	siblings := m.siblingsOf(b)
+	i := indexOf(siblings, b)
+	if i < 0 {
+		return
+	}
+	m.setSiblings(b.Parent, append(append([]*Bullet{}, siblings[:i]...), siblings[i+1:]...))
+}
+
+// insertAt places bullets into parent's children (or the roots) at index.
+func (m *Model) insertAt(parent *Bullet, index int, bullets []*Bullet) {
+	siblings := m.childrenOf(parent)
+	updated := append(append([]*Bullet{}, siblings[:index]...), bullets...)
+	m.setSiblings(parent, append(updated, siblings[index:]...))
+}
+
+func indexOf(bullets []*Bullet, b *Bullet) int {
+	for i, candidate := range bullets {
+		if candidate == b {
+			return i
+		}
+	}
+	return -1
+}
+
+// afterRangeChange rebuilds the visible list and puts the cursor back on
+// cursor, leaving visual mode if the anchor has disappeared.
+func (m *Model) afterRangeChange(cursor *Bullet) {
+	m.rebuildVisibleList()
+	m.selectBullet(cursor)
+	if _, _, ok := m.visualRange(); !ok {
+		m.stopVisual()
+	}
+}
+
+// indentRange makes every selected root a child of its previous sibling.
+// Consecutive roots end up under the same new parent, so the selection keeps
+// its shape.
+func (m *Model) indentRange() {
+	roots := m.rangeRoots()
+	if len(roots) == 0 {
+		return
+	}
+	for _, b := range roots {
+		if indexOf(m.siblingsOf(b), b) < 1 {
+			m.statusMessage = "Cannot indent: a selected bullet has no previous sibling"
+			return
+		}
+	}
+	m.recordUndo()
+
+	cursor := m.getSelectedBullet()
+	for _, b := range roots {
+		siblings := m.siblingsOf(b)
+		prevSibling := siblings[indexOf(siblings, b)-1]
+		m.detach(b)
+		prevSibling.AddChild(b)
+		prevSibling.Collapsed = false
+	}
+	m.afterRangeChange(cursor)
+}
+
+// outdentRange moves every selected root to just after its parent. Roots are
+// handled last to first so siblings keep their order.
+func (m *Model) outdentRange() {
+	roots := m.rangeRoots()
+	if len(roots) == 0 {
+		return
+	}
+	for _, b := range roots {
+		if b.Parent == nil {
+			m.statusMessage = "Cannot outdent: a selected bullet is already at the top level"
+			return
+		}
+	}
+	m.recordUndo()
+
+	cursor := m.getSelectedBullet()
+	for i := len(roots) - 1; i >= 0; i-- {
+		b := roots[i]
+		parent := b.Parent
+		m.detach(b)
+		m.insertAt(parent.Parent, indexOf(m.siblingsOf(parent), parent)+1, []*Bullet{b})
+	}
+	m.afterRangeChange(cursor)
+}
+
+// moveRange moves the selected siblings as one block past the previous (or
+// next) visible bullet at their depth, falling back to the parent level like
+// moveBulletUp and moveBulletDown do.
+func (m *Model) moveRange(up bool) {
+	roots := m.rangeRoots()
+	if len(roots) == 0 {
+		return
+	}
+	for _, b := range roots[1:] {
+		if b.Parent != roots[0].Parent {
+			m.statusMessage = "Select bullets at one level to move them together"
+			return
+		}
+	}
+
+	first := indexOf(m.allBullets, roots[0])
+	last := indexOf(m.allBullets, roots[len(roots)-1])
+	last += len(roots[len(roots)-1].GetVisibleDescendants())
+
+	depth := m.bulletDepth(roots[0])
+	var target *Bullet
+	for _, targetDepth := range []int{depth, depth - 1} {
+		if up {
+			for i := first - 1; i >= 0 && target == nil; i-- {
+				if m.bulletDepth(m.allBullets[i]) == targetDepth {
+					target = m.allBullets[i]
+				}
+			}
+		} else {
+			for i := last + 1; i < len(m.allBullets) && target == nil; i++ {
+				if m.bulletDepth(m.allBullets[i]) == targetDepth {
+					target = m.allBullets[i]
+				}
+			}
+		}
+		if target != nil || targetDepth == 0 {
+			break
+		}
+	}
+	if target == nil || target == m.zoomedBullet {
+		return
+	}
+	m.recordUndo()
+
+	cursor := m.getSelectedBullet()
+	for _, b := range roots {
+		m.detach(b)
+	}
+	index := indexOf(m.siblingsOf(target), target)
+	if !up {
+		index++
+	}
+	m.insertAt(target.Parent, index, roots)
+	m.afterRangeChange(cursor)
+}
+
+// deleteRange moves the selection to the trash and leaves visual mode.
+func (m *Model) deleteRange() {
+	roots := m.rangeRoots()
+	if len(roots) == 0 {
+		return
+	}
+	first, _, _ := m.visualRange()
+	m.recordUndo()
+
+	m.removeBullets(roots, &m.trash)
+	m.stopVisual()
+
+	m.rebuildVisibleList()
+	m.selectedIndex = first
+	m.clampSelection()
+	m.statusMessage = fmt.Sprintf("Moved %d bullets to trash (T to view)", len(roots))
+
+	// Auto-save after deleting bullets
+	m.saveData()
+}
+
+// colorRange gives every selected bullet the color after the first one's.
+func (m *Model) colorRange() {
+	bullets := m.rangeBullets()
+	if len(bullets) == 0 {
+		return
+	}
+	m.recordUndo()
+	bullets[0].CycleColor()
+	for _, b := range bullets[1:] {
+		b.Color = bullets[0].Color
+	}
+}
+
+// toggleTaskRange turns the selection into tasks, or back into plain bullets
+// when the first selected bullet is already a task.
+func (m *Model) toggleTaskRange() {
+	bullets := m.rangeBullets()
+	if len(bullets) == 0 {
+		return
+	}
+	m.recordUndo()
+	makeTasks := !bullets[0].IsTask
+	for _, b := range bullets {
+		if b.IsTask != makeTasks {
+			b.ToggleTask()
+		}
+	}
+}
+
+// toggleCompleteRange completes every selected task, or reopens them all if
+// they are already completed.
+func (m *Model) toggleCompleteRange() {
+	var tasks []*Bullet
+	complete := false
+	for _, b := range m.rangeBullets() {
+		if b.IsTask {
+			tasks = append(tasks, b)
+			complete = complete || !b.Completed
+		}
+	}
+	if len(tasks) == 0 {
+		return
+	}
+	m.recordUndo()
+	for _, b := range tasks {
+		if b.Completed != complete {
+			b.ToggleComplete()
+		}
+	}
+}
+
+// updateVisual handles keys while a range is selected. Keys without a bulk
+// meaning are ignored rather than applied to the cursor alone.
+func (m Model) updateVisual(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "V":
+		m.stopVisual()
+
+	case "q", "ctrl+c":
+		// Save data before quitting
+		m.saveData()
+		return m, tea.Quit
+
+	case "up", "k":
+		if m.selectedIndex > 0 {
+			m.selectedIndex--
+			m.ensureSelectedVisible()
+		}
+
+	case "down", "j":
+		if m.selectedIndex < len(m.allBullets)-1 {
+			m.selectedIndex++
+			m.ensureSelectedVisible()
+		}
+
+	case "tab":
+		m.indentRange()
+
+	case "shift+tab":
+		m.outdentRange()
+
+	case "shift+up":
+		m.moveRange(true)
+
+	case "shift+down":
+		m.moveRange(false)
+
+	case "c":
+		m.colorRange()
+
+	case "t":
+		m.toggleTaskRange()
+
+	case "x":
+		m.toggleCompleteRange()
+
+	case "d":
+		m.deleteRange()
+
+	case "y":
+		m.yank()
+
+	case "D":
+		m.cut()
+
+	case "A":
+		m.archiveBullets(m.rangeRoots())
+
+	case "m":
+		return m, m.openRefile(pickMoveTo)
+
+	case "M":
+		return m, m.openRefile(pickDuplicateTo)
+	}
+	return m, nil
+}
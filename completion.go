@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const bashCompletion = `# bash completion for ocli
+# Install: source <(ocli completion bash)
+
+# _ocli_bullets KIND fills COMPREPLY with the bullets whose ID starts with
+# the current word. Like zsh and fish, the bullet's path is shown next to
+# each ID; it is only dropped when a single match is left to insert.
+_ocli_bullets() {
+    local cur="${COMP_WORDS[COMP_CWORD]}" id path
+    local -a matches=()
+    while IFS=$'\t' read -r id path; do
+        [[ "$id" == "$cur"* ]] && matches+=("$id  ($path)")
+    done < <(ocli __complete "$1" 2>/dev/null)
+
+    if [[ ${#matches[@]} -eq 1 ]]; then
+        COMPREPLY=("${matches[0]%%  (*}")
+    else
+        COMPREPLY=("${matches[@]}")
+    fi
+}
+
+_ocli() {
+    local cur="${COMP_WORDS[COMP_CWORD]}"
+    local prev="${COMP_WORDS[COMP_CWORD-1]}"
+
+    if [[ $COMP_CWORD -eq 1 ]]; then
+        COMPREPLY=($(compgen -W "ctl doctor stats completion --version --help --readonly" -- "$cur"))
+        return
+    fi
+
+    case "${COMP_WORDS[1]}" in
+    ctl)
+        if [[ $COMP_CWORD -eq 2 ]]; then
+            COMPREPLY=($(compgen -W "add complete zoom search help" -- "$cur"))
+        elif [[ "$prev" == "--parent" ]]; then
+            _ocli_bullets bullets
+        elif [[ $COMP_CWORD -eq 3 ]]; then
+            case "${COMP_WORDS[2]}" in
+            complete) _ocli_bullets tasks ;;
+            zoom) _ocli_bullets bullets ;;
+            add) COMPREPLY=($(compgen -W "--parent" -- "$cur")) ;;
+            esac
+        fi
+        ;;
+    doctor)
+        COMPREPLY=($(compgen -W "--fix" -- "$cur"))
+        ;;
+    stats)
+        if [[ "$prev" == "--id" ]]; then
+            _ocli_bullets bullets
+        else
+            COMPREPLY=($(compgen -W "--json --id" -- "$cur"))
+        fi
+        ;;
+    completion)
+        if [[ $COMP_CWORD -eq 2 ]]; then
+            COMPREPLY=($(compgen -W "bash zsh fish" -- "$cur"))
+        fi
+        ;;
+    esac
+}
+
+complete -F _ocli ocli
+`
+
+const zshCompletion = `#compdef ocli
+# zsh completion for ocli
+# Install: source <(ocli completion zsh)
+
+_ocli_bullets() {
+    local -a items
+    items=(${(f)"$(ocli __complete $1 2>/dev/null | tr '\t' ':')"})
+    _describe -t bullets 'bullet' items
+}
+
+_ocli() {
+    if (( CURRENT == 2 )); then
+        if [[ $PREFIX == -* ]]; then
+            compadd -- --version --help --readonly
+        else
+            local -a commands
+            commands=(
+                'ctl:Control a running OCLI'
+                'doctor:Check the data file for problems'
+                'stats:Show outline statistics'
+                'completion:Print a shell completion script'
+            )
+            _describe -t commands 'command' commands
+        fi
+        return
+    fi
+
+    case $words[2] in
+    ctl)
+        if (( CURRENT == 3 )); then
+            local -a subcommands
+            subcommands=(
+                'add:Add a bullet'
+                'complete:Mark a task as completed'
+                'zoom:Zoom to a bullet'
+                'search:List matching bullets'
+                'help:Show ctl usage'
+            )
+            _describe -t commands 'ctl command' subcommands
+        elif [[ $words[CURRENT-1] == --parent ]]; then
+            _ocli_bullets bullets
+        elif (( CURRENT == 4 )); then
+            case $words[3] in
+            complete) _ocli_bullets tasks ;;
+            zoom) _ocli_bullets bullets ;;
+            add) compadd -- --parent ;;
+            esac
+        fi
+        ;;
+    doctor)
+        compadd -- --fix
+        ;;
+    stats)
+        if [[ $words[CURRENT-1] == --id ]]; then
+            _ocli_bullets bullets
+        else
+            compadd -- --json --id
+        fi
+        ;;
+    completion)
+        (( CURRENT == 3 )) && compadd bash zsh fish
+        ;;
+    esac
+}
+
+if [[ "$funcstack[1]" == "_ocli" ]]; then
+    _ocli "$@"
+else
+    compdef _ocli ocli
+fi
+`
+
+const fishCompletion = `# fish completion for ocli
+# Install: ocli completion fish > ~/.config/fish/completions/ocli.fish
+
+function __ocli_bullets
+    ocli __complete $argv[1] 2>/dev/null
+end
+
+complete -c ocli -f
+
+complete -c ocli -n __fish_use_subcommand -a ctl -d 'Control a running OCLI'
+complete -c ocli -n __fish_use_subcommand -a doctor -d 'Check the data file for problems'
+complete -c ocli -n __fish_use_subcommand -a stats -d 'Show outline statistics'
+complete -c ocli -n __fish_use_subcommand -a completion -d 'Print a shell completion script'
+complete -c ocli -n __fish_use_subcommand -l version -d 'Show version information'
+complete -c ocli -n __fish_use_subcommand -l help -d 'Show help information'
+complete -c ocli -n __fish_use_subcommand -l readonly -d 'View the outline without allowing changes'
+
+set -l ctl_commands add complete zoom search help
+complete -c ocli -n "__fish_seen_subcommand_from ctl; and not __fish_seen_subcommand_from $ctl_commands" -a add -d 'Add a bullet'
+complete -c ocli -n "__fish_seen_subcommand_from ctl; and not __fish_seen_subcommand_from $ctl_commands" -a complete -d 'Mark a task as completed'
+complete -c ocli -n "__fish_seen_subcommand_from ctl; and not __fish_seen_subcommand_from $ctl_commands" -a zoom -d 'Zoom to a bullet'
+complete -c ocli -n "__fish_seen_subcommand_from ctl; and not __fish_seen_subcommand_from $ctl_commands" -a search -d 'List matching bullets'
+complete -c ocli -n "__fish_seen_subcommand_from ctl; and not __fish_seen_subcommand_from $ctl_commands" -a help -d 'Show ctl usage'
+complete -c ocli -n '__fish_seen_subcommand_from ctl; and __fish_seen_subcommand_from complete' -a '(__ocli_bullets tasks)'
+complete -c ocli -n '__fish_seen_subcommand_from ctl; and __fish_seen_subcommand_from zoom' -a '(__ocli_bullets bullets)'
+complete -c ocli -n '__fish_seen_subcommand_from ctl; and __fish_seen_subcommand_from add' -l parent -x -a '(__ocli_bullets bullets)' -d 'Parent bullet'
+
+complete -c ocli -n '__fish_seen_subcommand_from doctor' -l fix -d 'Repair problems after a backup'
+
+complete -c ocli -n '__fish_seen_subcommand_from stats' -l json -d 'Print statistics as JSON'
+complete -c ocli -n '__fish_seen_subcommand_from stats' -l id -x -a '(__ocli_bullets bullets)' -d 'Only this subtree'
+
+complete -c ocli -n '__fish_seen_subcommand_from completion' -a 'bash zsh fish'
+`
+
+// runCompletion implements `ocli completion <shell>`.
+func runCompletion(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: ocli completion bash|zsh|fish")
+	}
+
+	switch args[0] {
+	case "bash":
+		fmt.Print(bashCompletion)
+	case "zsh":
+		fmt.Print(zshCompletion)
+	case "fish":
+		fmt.Print(fishCompletion)
+	default:
+		return fmt.Errorf("unsupported shell %q (use bash, zsh or fish)", args[0])
+	}
+	return nil
+}
+
+// runComplete implements the hidden `ocli __complete <bullets|tasks>` command
+// used by the completion scripts. It prints "ID<TAB>path" lines, where ID is
+// the shortest unambiguous prefix of at least minIDPrefix characters.
+func runComplete(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: ocli __complete bullets|tasks")
+	}
+	kind := args[0]
+
+	configManager, err := NewConfigManager()
+	if err != nil {
+		return err
+	}
+	data, err := configManager.Load()
+	if err != nil {
+		return err
+	}
+
+	for _, candidate := range completionCandidates(data.RootBullets, kind) {
+		fmt.Println(candidate)
+	}
+	return nil
+}
+
+func completionCandidates(roots []*Bullet, kind string) []string {
+	var ids []string
+	walkBullets(roots, func(b *Bullet) {
+		ids = append(ids, b.ID)
+	})
+	prefixes := shortIDPrefixes(ids)
+
+	var candidates []string
+	walkBullets(roots, func(b *Bullet) {
+		if kind == "tasks" && (!b.IsTask || b.Completed) {
+			return
+		}
+		path := strings.NewReplacer("\t", " ", "\n", " ").Replace(b.PathString())
+		candidates = append(candidates, prefixes[b.ID]+"\t"+path)
+	})
+	return candidates
+}
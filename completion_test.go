@@ -0,0 +1,98 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShortIDPrefixes(t *testing.T) {
+	tests := []struct {
+		name     string
+		ids      []string
+		expected map[string]string
+	}{
+		{
+			name:     "single ID",
+			ids:      []string{"0123456789abcdef"},
+			expected: map[string]string{"0123456789abcdef": "01234567"},
+		},
+		{
+			name: "distinct within the minimum prefix",
+			ids:  []string{"aaaaaaaa1111", "bbbbbbbb2222"},
+			expected: map[string]string{
+				"aaaaaaaa1111": "aaaaaaaa",
+				"bbbbbbbb2222": "bbbbbbbb",
+			},
+		},
+		{
+			name: "shared long prefix",
+			ids:  []string{"0123456789abX", "0123456789abY", "01234567zzzz"},
+			expected: map[string]string{
+				"0123456789abX": "0123456789abX",
+				"0123456789abY": "0123456789abY",
+				"01234567zzzz":  "01234567z",
+			},
+		},
+		{
+			name: "one ID is a prefix of another",
+			ids:  []string{"abcdefgh1", "abcdefgh12", "abcdefgh2"},
+			expected: map[string]string{
+				"abcdefgh1":  "abcdefgh1",
+				"abcdefgh12": "abcdefgh12",
+				"abcdefgh2":  "abcdefgh2",
+			},
+		},
+		{
+			name:     "ID shorter than the minimum prefix",
+			ids:      []string{"abc", "abd"},
+			expected: map[string]string{"abc": "abc", "abd": "abd"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := shortIDPrefixes(tt.ids)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("shortIDPrefixes(%v) = %v, want %v", tt.ids, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompletionCandidates(t *testing.T) {
+	work := &Bullet{ID: "11111111aaaa", Content: "Work"}
+	open := &Bullet{ID: "11111111bbbb", Content: "Open\ttask", IsTask: true}
+	done := &Bullet{ID: "22222222cccc", Content: "Done task", IsTask: true, Completed: true}
+	work.AddChild(open)
+	work.AddChild(done)
+	home := &Bullet{ID: "33333333dddd", Content: "Home"}
+	roots := []*Bullet{work, home}
+
+	tests := []struct {
+		kind     string
+		expected []string
+	}{
+		{
+			kind: "bullets",
+			expected: []string{
+				"11111111a\tWork",
+				"11111111b\tWork > Open task",
+				"22222222\tWork > Done task",
+				"33333333\tHome",
+			},
+		},
+		{
+			kind:     "tasks",
+			expected: []string{"11111111b\tWork > Open task"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			got := completionCandidates(roots, tt.kind)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("completionCandidates(%q) = %q, want %q", tt.kind, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+const ctlUsage = `Usage: ocli ctl <command> [arguments]
+
+Send commands to a running OCLI instance.
+
+Commands:
+  add [--parent ID] TEXT   Add a bullet at the top level or under ID
+  complete ID              Mark a task as completed
+  zoom [ID]                Zoom to a bullet (no ID zooms out to the top)
+  search QUERY             List bullets whose content contains QUERY
+
+IDs may be shortened to any unambiguous prefix. While a bullet or note is
+being edited, or a prompt, picker or panel is open, only search is accepted.`
+
+// runCtl implements `ocli ctl`, which talks to the TUI over its control socket.
+func runCtl(args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "--help" {
+		fmt.Println(ctlUsage)
+		return nil
+	}
+
+	configManager, err := NewConfigManager()
+	if err != nil {
+		return err
+	}
+
+	var command RemoteCommand
+	switch args[0] {
+	case "add":
+		flags := flag.NewFlagSet("ctl add", flag.ContinueOnError)
+		parent := flags.String("parent", "", "ID of the parent bullet")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		command = RemoteCommand{
+			Action:  "add",
+			Parent:  *parent,
+			Content: strings.Join(flags.Args(), " "),
+		}
+
+	case "complete":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: ocli ctl complete ID")
+		}
+		command = RemoteCommand{Action: "complete", ID: args[1]}
+
+	case "zoom":
+		command = RemoteCommand{Action: "zoom"}
+		if len(args) > 1 {
+			command.ID = args[1]
+		}
+
+	case "search":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: ocli ctl search QUERY")
+		}
+		command = RemoteCommand{Action: "search", Query: strings.Join(args[1:], " ")}
+
+	default:
+		return fmt.Errorf("unknown ctl command %q (see 'ocli ctl help')", args[0])
+	}
+
+	response, err := SendRemoteCommand(configManager.SocketPath(), command)
+	if err != nil {
+		return err
+	}
+	if !response.OK {
+		return fmt.Errorf("%s", response.Error)
+	}
+
+	if response.ID != "" {
+		fmt.Println(response.ID)
+	}
+	for _, match := range response.Matches {
+		fmt.Printf("%s\t%s\n", match.ID, match.Path)
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Problem is a single integrity issue found in the outline.
+type Problem struct {
+	Path    string
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Path, p.Message)
+}
+
+// diagnosis walks a freshly decoded tree, collecting (and optionally fixing)
+// problems. Parent references are not trusted, so paths are tracked explicitly.
+type diagnosis struct {
+	fix      bool
+	seen     map[string]bool
+	visited  map[*Bullet]bool
+	problems []Problem
+}
+
+// diagnoseData validates the tree (unique non-empty IDs, children arrays,
+// color range, completion only on tasks, no cycles, no stale editing state),
+// the branches in the trash and archive the same way, and that bookmarks
+// point to existing bullets. With fix set, every problem is repaired in place
+// as it is found.
+func diagnoseData(data *AppData, fix bool) []Problem {
+	d := &diagnosis{
+		fix:     fix,
+		seen:    make(map[string]bool),
+		visited: make(map[*Bullet]bool),
+	}
+	data.RootBullets = d.checkList(data.RootBullets, nil, nil)
+	data.Trash = d.checkRemoved(data.Trash, "Trash")
+	data.Archive = d.checkRemoved(data.Archive, "Archive")
+	data.Bookmarks = d.checkBookmarks(data.Bookmarks, data)
+	return d.problems
+}
+
+func (d *diagnosis) report(path []string, format string, args ...interface{}) {
+	d.problems = append(d.problems, Problem{
+		Path:    strings.Join(path, " > "),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (d *diagnosis) checkList(bullets []*Bullet, path []string, ancestorIDs map[string]bool) []*Bullet {
+	kept := make([]*Bullet, 0, len(bullets))
+	for i, b := range bullets {
+		label := fmt.Sprintf("#%d", i+1)
+		if b != nil && strings.TrimSpace(b.Content) != "" {
+			label = b.Content
+		}
+		bulletPath := append(append([]string{}, path...), label)
+
+		if b == nil {
+			d.report(bulletPath, "null bullet entry")
+			if d.fix {
+				continue
+			}
+		} else if d.visited[b] {
+			d.report(bulletPath, "bullet appears in the tree more than once")
+			if d.fix {
+				continue
+			}
+		} else {
+			d.visited[b] = true
+			d.checkBullet(b, bulletPath, ancestorIDs)
+		}
+		kept = append(kept, b)
+	}
+	if d.fix {
+		return kept
+	}
+	return bullets
+}
+
+func (d *diagnosis) checkBullet(b *Bullet, path []string, ancestorIDs map[string]bool) {
+	switch {
+	case b.ID == "":
+		d.report(path, "empty ID")
+		if d.fix {
+			b.ID = uuid.New().String()
+		}
+	case ancestorIDs[b.ID]:
+		d.report(path, "cycle: ID %s is also used by an ancestor", b.ID)
+		if d.fix {
+			b.ID = uuid.New().String()
+		}
+	case d.seen[b.ID]:
+		d.report(path, "duplicate ID %s", b.ID)
+		if d.fix {
+			b.ID = uuid.New().String()
+		}
+	}
+	d.seen[b.ID] = true
+
+	if b.Children == nil {
+		d.report(path, "missing children array")
+		if d.fix {
+			b.Children = make([]*Bullet, 0)
+		}
+	}
+
+	if b.Color < ColorDefault || b.Color > ColorRed {
+		d.report(path, "invalid color %d", b.Color)
+		if d.fix {
+			b.Color = ColorDefault
+		}
+	}
+
+	if b.Completed && !b.IsTask {
+		d.report(path, "marked completed but is not a task")
+		if d.fix {
+			b.Completed = false
+		}
+	}
+
+	if b.IsEditing {
+		d.report(path, "stale editing flag")
+		if d.fix {
+			b.IsEditing = false
+		}
+	}
+
+	childAncestors := make(map[string]bool, len(ancestorIDs)+1)
+	for id := range ancestorIDs {
+		childAncestors[id] = true
+	}
+	childAncestors[b.ID] = true
+	b.Children = d.checkList(b.Children, path, childAncestors)
+}
+
+// checkRemoved validates trash or archive entries. Their IDs only need to
+// be unique within the list, since the outline may hold a copy of a bullet
+// that was restored and deleted again.
+func (d *diagnosis) checkRemoved(entries []*RemovedBullet, name string) []*RemovedBullet {
+	seen := d.seen
+	d.seen = make(map[string]bool)
+	defer func() { d.seen = seen }()
+
+	kept := make([]*RemovedBullet, 0, len(entries))
+	for i, entry := range entries {
+		if entry == nil || entry.Bullet == nil {
+			d.report([]string{name, fmt.Sprintf("#%d", i+1)}, "entry without a bullet")
+			if d.fix {
+				continue
+			}
+			kept = append(kept, entry)
+			continue
+		}
+
+		if entry.Index < 0 {
+			label := fmt.Sprintf("#%d", i+1)
+			if strings.TrimSpace(entry.Bullet.Content) != "" {
+				label = entry.Bullet.Content
+			}
+			d.report([]string{name, label}, "invalid position %d", entry.Index)
+			if d.fix {
+				entry.Index = 0
+			}
+		}
+		d.checkList([]*Bullet{entry.Bullet}, []string{name}, nil)
+		kept = append(kept, entry)
+	}
+	if d.fix {
+		return kept
+	}
+	return entries
+}
+
+// checkBookmarks reports bookmarks whose bullet is neither in the outline
+// nor in the trash or archive, and bookmarks listed twice.
+func (d *diagnosis) checkBookmarks(bookmarks []string, data *AppData) []string {
+	known := make(map[string]bool)
+	var collect func(bullets []*Bullet)
+	collect = func(bullets []*Bullet) {
+		for _, b := range bullets {
+			if b != nil {
+				known[b.ID] = true
+				collect(b.Children)
+			}
+		}
+	}
+	collect(data.RootBullets)
+	for _, entry := range append(append([]*RemovedBullet{}, data.Trash...), data.Archive...) {
+		if entry != nil {
+			collect([]*Bullet{entry.Bullet})
+		}
+	}
+
+	path := []string{"Bookmarks"}
+	listed := make(map[string]bool)
+	kept := make([]string, 0, len(bookmarks))
+	for _, id := range bookmarks {
+		switch {
+		case !known[id]:
+			d.report(path, "bookmark points to missing bullet %s", id)
+			if d.fix {
+				continue
+			}
+		case listed[id]:
+			d.report(path, "duplicate bookmark %s", id)
+			if d.fix {
+				continue
+			}
+		}
+		listed[id] = true
+		kept = append(kept, id)
+	}
+	if d.fix {
+		return kept
+	}
+	return bookmarks
+}
+
+// runDoctor implements `ocli doctor [--fix]`.
+func runDoctor(args []string) error {
+	flags := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	fix := flags.Bool("fix", false, "Repair problems after backing up the data file")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	configManager, err := NewConfigManager()
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(configManager.configFile); os.IsNotExist(err) {
+		fmt.Printf("No data file at %s, nothing to check\n", configManager.configFile)
+		return nil
+	}
+
+	data, err := configManager.readDataFile()
+	if err != nil {
+		return err
+	}
+
+	problems := diagnoseData(data, *fix)
+	if len(problems) == 0 {
+		fmt.Printf("No problems found in %s\n", configManager.configFile)
+		return nil
+	}
+
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+
+	if !*fix {
+		fmt.Println("\nRun 'ocli doctor --fix' to repair these problems")
+		return fmt.Errorf("found %d problem(s)", len(problems))
+	}
+
+	backupFile, err := configManager.Backup()
+	if err != nil {
+		return err
+	}
+	configManager.restoreParentRelationships(data)
+	if err := configManager.Save(data); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nFixed %d problem(s), backup saved to %s\n", len(problems), backupFile)
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiagnoseData(t *testing.T) {
+	child := &Bullet{ID: "a", Content: "Child", Children: []*Bullet{}}
+	duplicate := &Bullet{ID: "b", Content: "Duplicate", Children: []*Bullet{}}
+	data := &AppData{
+		RootBullets: []*Bullet{
+			{
+				ID:        "a",
+				Content:   "Root",
+				Children:  []*Bullet{child, nil},
+				Color:     BulletColor(9),
+				Completed: true,
+			},
+			{ID: "b", Content: "Other", IsEditing: true},
+			duplicate,
+			{ID: "", Content: "", Children: []*Bullet{}},
+		},
+	}
+
+	problems := diagnoseData(data, false)
+	expected := []string{
+		"Root: invalid color 9",
+		"Root: marked completed but is not a task",
+		"Root > Child: cycle: ID a is also used by an ancestor",
+		"Root > #2: null bullet entry",
+		"Other: missing children array",
+		"Other: stale editing flag",
+		"Duplicate: duplicate ID b",
+		"#4: empty ID",
+	}
+	if len(problems) != len(expected) {
+		t.Fatalf("Expected %d problems, got %d: %v", len(expected), len(problems), problems)
+	}
+	for i, want := range expected {
+		if problems[i].String() != want {
+			t.Errorf("Problem %d: expected %q, got %q", i, want, problems[i].String())
+		}
+	}
+
+	// Checking alone must not modify anything
+	if child.ID != "a" || data.RootBullets[0].Color != 9 {
+		t.Error("Check without fix modified the tree")
+	}
+
+	fixed := diagnoseData(data, true)
+	if len(fixed) != len(expected) {
+		t.Errorf("Expected fix pass to report %d problems, got %d", len(expected), len(fixed))
+	}
+	if remaining := diagnoseData(data, false); len(remaining) != 0 {
+		t.Errorf("Expected no problems after fix, got %v", remaining)
+	}
+
+	if len(data.RootBullets[0].Children) != 1 {
+		t.Error("Expected null child entry to be dropped")
+	}
+	if child.ID == "a" || duplicate.ID == "b" || strings.TrimSpace(data.RootBullets[3].ID) == "" {
+		t.Error("Expected conflicting and empty IDs to be regenerated")
+	}
+}
+
+func TestDiagnoseRemovedAndBookmarks(t *testing.T) {
+	kept := &Bullet{ID: "k", Content: "Kept", Children: []*Bullet{}}
+	trashed := &Bullet{ID: "t", Content: "Trashed", Children: []*Bullet{}}
+	data := &AppData{
+		RootBullets: []*Bullet{kept},
+		Trash: []*RemovedBullet{
+			{Bullet: trashed, Index: -1},
+			{Bullet: nil},
+		},
+		Archive: []*RemovedBullet{
+			{Bullet: &Bullet{ID: "a", Content: "Old", Color: BulletColor(7), Children: []*Bullet{}}},
+		},
+		Bookmarks: []string{"k", "t", "gone", "k"},
+	}
+
+	problems := diagnoseData(data, false)
+	expected := []string{
+		"Trash > Trashed: invalid position -1",
+		"Trash > #2: entry without a bullet",
+		"Archive > Old: invalid color 7",
+		"Bookmarks: bookmark points to missing bullet gone",
+		"Bookmarks: duplicate bookmark k",
+	}
+	if len(problems) != len(expected) {
+		t.Fatalf("Expected %d problems, got %d: %v", len(expected), len(problems), problems)
+	}
+	for i, want := range expected {
+		if problems[i].String() != want {
+			t.Errorf("Problem %d: expected %q, got %q", i, want, problems[i].String())
+		}
+	}
+
+	diagnoseData(data, true)
+	if remaining := diagnoseData(data, false); len(remaining) != 0 {
+		t.Errorf("Expected no problems after fix, got %v", remaining)
+	}
+	if len(data.Trash) != 1 || len(data.Bookmarks) != 2 || data.Bookmarks[1] != "t" {
+		t.Errorf("Expected the empty entry and bad bookmarks to be dropped, got %d entries and %v", len(data.Trash), data.Bookmarks)
+	}
+}
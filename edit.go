@@ -0,0 +1,94 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// startEdit opens the inline editor on b with the cursor at rune position cursor.
+func (m *Model) startEdit(b *Bullet, cursor int) tea.Cmd {
+	m.editMode = EditModeEdit
+	m.editingBullet = b
+	b.IsEditing = true
+	m.keepInFilter(b)
+	m.textInput.Width = m.contentWidth(m.bulletDepth(b), lipgloss.Width(bulletPrefix(b)+m.textInput.Prompt)) - 1
+	m.textInput.SetValue(b.Content)
+	m.textInput.Focus()
+	m.textInput.SetCursor(cursor)
+	return textinput.Blink
+}
+
+// splitBullet splits the bullet being edited at the cursor. The text before
+// the cursor stays with the bullet, together with its children, note and
+// completion; the rest becomes a new next sibling with the same color and
+// task type, which is then edited with the cursor at its start.
+func (m *Model) splitBullet() tea.Cmd {
+	current := m.editingBullet
+	value := []rune(m.textInput.Value())
+	cursor := m.textInput.Position()
+	m.recordUndo()
+
+	current.Content = strings.TrimRight(string(value[:cursor]), " ")
+	current.IsEditing = false
+
+	next := NewBullet(strings.TrimLeft(string(value[cursor:]), " "))
+	next.Color = current.Color
+	next.IsTask = current.IsTask
+	if current == m.zoomedBullet {
+		// The zoomed bullet has no visible siblings, so split into its first child
+		m.insertAt(current, 0, []*Bullet{next})
+	} else {
+		m.insertAt(current.Parent, indexOf(m.siblingsOf(current), current)+1, []*Bullet{next})
+	}
+
+	m.rebuildVisibleList()
+	m.selectBullet(next)
+
+	// Auto-save after splitting bullet
+	m.saveData()
+	return m.startEdit(next, 0)
+}
+
+// joinBullet merges the bullet being edited into the previous visible bullet,
+// which keeps its own color and task state. The text is appended, notes are
+// concatenated and children are taken over by the previous bullet; when that
+// is the parent they keep the merged bullet's position among its children.
+func (m *Model) joinBullet() tea.Cmd {
+	current := m.editingBullet
+	index := indexOf(m.allBullets, current)
+	if index < 1 || current == m.zoomedBullet {
+		return nil
+	}
+	prev := m.allBullets[index-1]
+	m.recordUndo()
+
+	cursor := len([]rune(prev.Content))
+	prev.Content += m.textInput.Value()
+	if current.Note != "" {
+		if prev.Note != "" {
+			prev.Note += "\n"
+		}
+		prev.Note += current.Note
+	}
+
+	position := len(prev.Children)
+	if current.Parent == prev {
+		position = indexOf(prev.Children, current)
+	}
+	m.detach(current)
+	m.insertAt(prev, position, current.Children)
+	if len(current.Children) > 0 {
+		prev.Collapsed = false
+	}
+	current.IsEditing = false
+
+	m.rebuildVisibleList()
+	m.selectBullet(prev)
+
+	// Auto-save after joining bullets
+	m.saveData()
+	return m.startEdit(prev, cursor)
+}
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Bullets are written one per line, indented by two spaces per level, with
+// their (shortened) ID at the end of the line, e.g.
+//
+//	Launch plan {#3f2a9c1e}
+//	  [ ] Book venue {#a1b2c3d4}
+//
+// Lines without an ID marker become new bullets and bullets whose lines are
+// removed are deleted. Colors, notes and collapse state are kept by ID.
+const editorIndent = "  "
+
+var idMarkerPattern = regexp.MustCompile(`\s*\{#([0-9a-fA-F-]+)\}\s*$`)
+
+// editorFinishedMsg is sent when the external editor exits.
+type editorFinishedMsg struct {
+	bulletID string
+	path     string
+	original string
+	err      error
+}
+
+// outlineNode is one parsed line of the editor text.
+type outlineNode struct {
+	id        string // Short ID from the marker, empty for new lines
+	content   string
+	isTask    bool
+	completed bool
+	children  []*outlineNode
+}
+
+// formatSubtree renders b and its descendants in the editor format.
+func formatSubtree(b *Bullet) string {
+	var ids []string
+	walkBullets([]*Bullet{b}, func(d *Bullet) {
+		ids = append(ids, d.ID)
+	})
+	prefixes := shortIDPrefixes(ids)
+
+	var text strings.Builder
+	var write func(d *Bullet, depth int)
+	write = func(d *Bullet, depth int) {
+		text.WriteString(strings.Repeat(editorIndent, depth))
+		if d.IsTask {
+			if d.Completed {
+				text.WriteString("[x] ")
+			} else {
+				text.WriteString("[ ] ")
+			}
+		}
+		// Keep each bullet on one line
+		text.WriteString(strings.ReplaceAll(d.Content, "\n", " "))
+		fmt.Fprintf(&text, " {#%s}\n", prefixes[d.ID])
+		for _, child := range d.Children {
+			write(child, depth+1)
+		}
+	}
+	write(b, 0)
+	return text.String()
+}
+
+// parseOutline reads editor text back into a forest of nodes. Blank lines
+// are ignored. A line is a child of the closest line above it that is
+// indented less, so any consistent indentation works; tabs in the leading
+// whitespace count as one level each.
+func parseOutline(text string) []*outlineNode {
+	type level struct {
+		column int
+		node   *outlineNode
+	}
+	var roots []*outlineNode
+	var stack []level // The chain of open ancestors, least indented first
+
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		trimmed := strings.TrimLeft(line, " \t")
+		leading := line[:len(line)-len(trimmed)]
+		column := len(strings.ReplaceAll(leading, "\t", editorIndent))
+
+		node := &outlineNode{}
+		if match := idMarkerPattern.FindStringSubmatchIndex(trimmed); match != nil {
+			node.id = trimmed[match[2]:match[3]]
+			trimmed = trimmed[:match[0]]
+		}
+		switch {
+		case strings.HasPrefix(trimmed, "[ ] "):
+			node.isTask = true
+			trimmed = trimmed[4:]
+		case strings.HasPrefix(trimmed, "[x] "), strings.HasPrefix(trimmed, "[X] "):
+			node.isTask = true
+			node.completed = true
+			trimmed = trimmed[4:]
+		}
+		node.content = strings.TrimRight(trimmed, " ")
+
+		for len(stack) > 0 && stack[len(stack)-1].column >= column {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			roots = append(roots, node)
+		} else {
+			parent := stack[len(stack)-1].node
+			parent.children = append(parent.children, node)
+		}
+		stack = append(stack, level{column: column, node: node})
+	}
+	return roots
+}
+
+// applyOutline rebuilds the subtree rooted at b from edited nodes. Bullets
+// whose ID markers survive keep their identity, color, note and collapse
+// state wherever they were moved; lines without a known marker become new
+// bullets and anything not mentioned is dropped. The returned bullets take
+// b's place among its siblings.
+func applyOutline(b *Bullet, nodes []*outlineNode) []*Bullet {
+	return buildOutline(nodes, matchOutline(b, nodes))
+}
+
+// matchOutline pairs edited nodes with the bullets of the subtree rooted at b
+// by their ID markers. A marker copied to several lines only matches the
+// first; nodes without a match are left out.
+func matchOutline(b *Bullet, nodes []*outlineNode) map[*outlineNode]*Bullet {
+	var subtree []*Bullet
+	var ids []string
+	walkBullets([]*Bullet{b}, func(d *Bullet) {
+		subtree = append(subtree, d)
+		ids = append(ids, d.ID)
+	})
+	prefixes := shortIDPrefixes(ids)
+	existing := make(map[string]*Bullet)
+	for _, d := range subtree {
+		existing[d.ID] = d
+		existing[prefixes[d.ID]] = d
+	}
+
+	matches := make(map[*outlineNode]*Bullet)
+	used := make(map[*Bullet]bool)
+	var match func(nodes []*outlineNode)
+	match = func(nodes []*outlineNode) {
+		for _, node := range nodes {
+			if bullet := existing[node.id]; bullet != nil && !used[bullet] {
+				matches[node] = bullet
+				used[bullet] = true
+			}
+			match(node.children)
+		}
+	}
+	match(nodes)
+	return matches
+}
+
+// buildOutline turns edited nodes into bullets, reusing the matched ones.
+func buildOutline(nodes []*outlineNode, matches map[*outlineNode]*Bullet) []*Bullet {
+	bullets := make([]*Bullet, 0, len(nodes))
+	for _, node := range nodes {
+		bullet := matches[node]
+		if bullet == nil {
+			// Unknown or copied marker: this is a new bullet
+			bullet = NewBullet(node.content)
+		}
+
+		bullet.Content = node.content
+		bullet.IsTask = node.isTask
+		bullet.Completed = node.completed
+		bullet.Children = buildOutline(node.children, matches)
+		for _, child := range bullet.Children {
+			child.Parent = bullet
+		}
+		if len(bullet.Children) == 0 {
+			bullet.Collapsed = false
+		}
+		bullets = append(bullets, bullet)
+	}
+	return bullets
+}
+
+// droppedBranches returns the top bullets of every branch of the subtree
+// rooted at b that the edit no longer mentions. Their children are trimmed
+// to the dropped ones, since kept children are rebuilt elsewhere.
+func droppedBranches(b *Bullet, matches map[*outlineNode]*Bullet) []*Bullet {
+	kept := make(map[*Bullet]bool, len(matches))
+	for _, bullet := range matches {
+		kept[bullet] = true
+	}
+
+	var dropped, roots []*Bullet
+	walkBullets([]*Bullet{b}, func(d *Bullet) {
+		if kept[d] {
+			return
+		}
+		dropped = append(dropped, d)
+		if d == b || kept[d.Parent] {
+			roots = append(roots, d)
+		}
+	})
+	for _, d := range dropped {
+		var children []*Bullet
+		for _, child := range d.Children {
+			if !kept[child] {
+				children = append(children, child)
+			}
+		}
+		d.Children = children
+	}
+	return roots
+}
+
+// editorCommand returns the user's preferred editor.
+func editorCommand() []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(name)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// openInEditor writes the subtree to a temp file and suspends the TUI while
+// $EDITOR runs on it.
+func (m *Model) openInEditor(b *Bullet) tea.Cmd {
+	file, err := os.CreateTemp("", "ocli-*.txt")
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Could not create temp file: %v", err)
+		return nil
+	}
+
+	original := formatSubtree(b)
+	_, err = file.WriteString(original)
+	file.Close()
+	if err != nil {
+		os.Remove(file.Name())
+		m.statusMessage = fmt.Sprintf("Could not write temp file: %v", err)
+		return nil
+	}
+
+	editor := editorCommand()
+	cmd := exec.Command(editor[0], append(editor[1:], file.Name())...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorFinishedMsg{bulletID: b.ID, path: file.Name(), original: original, err: err}
+	})
+}
+
+// applyEditorResult reads the edited file back and merges it into the tree.
+func (m *Model) applyEditorResult(msg editorFinishedMsg) {
+	defer os.Remove(msg.path)
+
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Editor failed: %v", msg.err)
+		return
+	}
+
+	edited, err := os.ReadFile(msg.path)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Could not read edits: %v", err)
+		return
+	}
+	if string(edited) == msg.original {
+		m.statusMessage = "No changes"
+		return
+	}
+
+	target := findBulletByID(m.rootBullets, msg.bulletID)
+	if target == nil {
+		m.statusMessage = "Bullet no longer exists, edits discarded"
+		return
+	}
+
+	m.recordUndo()
+	parent := target.Parent
+	index := indexOf(m.siblingsOf(target), target)
+
+	// Lines deleted in the editor go to the trash like d does
+	nodes := parseOutline(string(edited))
+	matches := matchOutline(target, nodes)
+	dropped := droppedBranches(target, matches)
+	m.removeBullets(dropped, &m.trash)
+	m.detach(target)
+
+	replacement := buildOutline(nodes, matches)
+	linkParents(replacement, parent)
+	m.insertAt(parent, index, replacement)
+
+	if m.zoomedBullet != nil {
+		// The zoomed bullet may have been moved or deleted
+		m.zoomTo(findBulletByID(m.rootBullets, m.zoomedBullet.ID))
+	}
+	m.rebuildVisibleList()
+	if len(replacement) > 0 {
+		m.selectBullet(replacement[0])
+	} else if m.selectedIndex >= len(m.allBullets) {
+		m.selectedIndex = len(m.allBullets) - 1
+		if m.selectedIndex < 0 {
+			m.selectedIndex = 0
+		}
+	}
+	m.ensureSelectedVisible()
+	m.statusMessage = "Applied edits"
+	if len(dropped) > 0 {
+		m.statusMessage = fmt.Sprintf("Applied edits, moved %d bullets to trash (T to view)", len(dropped))
+	}
+
+	// Auto-save after applying edits
+	m.saveData()
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEditorRoundTrip(t *testing.T) {
+	project := NewBullet("Project")
+	design := NewBullet("Design")
+	design.Color = ColorGreen
+	design.Note = "Keep this note"
+	build := NewBullet("Build")
+	build.ToggleTask()
+	obsolete := NewBullet("Obsolete")
+	project.AddChild(design)
+	project.AddChild(build)
+	project.AddChild(obsolete)
+
+	text := formatSubtree(project)
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[2], "  [ ] Build {#") {
+		t.Fatalf("Unexpected editor text:\n%s", text)
+	}
+
+	// Rename the project, move "Build" under "Design" and complete it,
+	// delete "Obsolete" and add a new bullet
+	edited := strings.Join([]string{
+		strings.Replace(lines[0], "Project", "Launch", 1),
+		lines[1],
+		"  " + strings.Replace(lines[2], "[ ]", "[x]", 1),
+		"  Write docs",
+	}, "\n")
+
+	result := applyOutline(project, parseOutline(edited))
+	if len(result) != 1 || result[0] != project || project.Content != "Launch" {
+		t.Fatal("Expected the project bullet to be kept and renamed")
+	}
+	if len(project.Children) != 2 || project.Children[0] != design || project.Children[1].Content != "Write docs" {
+		t.Fatalf("Unexpected children after edit: %d", len(project.Children))
+	}
+	if design.Color != ColorGreen || design.Note != "Keep this note" {
+		t.Error("Expected color and note to survive the edit")
+	}
+	if len(design.Children) != 1 || design.Children[0] != build || build.Parent != design || !build.Completed {
+		t.Error("Expected build to be moved under design and completed")
+	}
+}
+
+func TestParseOutlineDuplicatesAndIndentation(t *testing.T) {
+	root := NewBullet("Root")
+	child := NewBullet("Child")
+	root.AddChild(child)
+	lines := strings.Split(strings.TrimSpace(formatSubtree(root)), "\n")
+
+	// A copied line becomes a new bullet; deeper indentation nests it
+	edited := lines[0] + "\n" + lines[1] + "\n" + "      " + strings.TrimSpace(lines[1])
+	result := applyOutline(root, parseOutline(edited))
+	if len(result) != 1 || len(root.Children) != 1 {
+		t.Fatalf("Unexpected structure: %d roots, %d children", len(result), len(root.Children))
+	}
+	copied := child.Children
+	if len(copied) != 1 || copied[0] == child || copied[0].Content != "Child" {
+		t.Error("Expected the copied line to become a new child of Child")
+	}
+}
+
+func TestParseOutlineIndentColumns(t *testing.T) {
+	// Lines at the same indent are siblings, however far they are indented
+	nodes := parseOutline("  One\n  Two\n      Deep\n    Child\n  Three")
+	if len(nodes) != 3 || nodes[0].content != "One" || nodes[2].content != "Three" {
+		t.Fatalf("Expected three top-level nodes, got %d", len(nodes))
+	}
+	if two := nodes[1]; len(two.children) != 2 || two.children[0].content != "Deep" || two.children[1].content != "Child" {
+		t.Errorf("Expected Deep and Child under Two, got %d children", len(two.children))
+	}
+
+	// Tabs indent, but tabs inside the text are kept
+	nodes = parseOutline("Root\n\tName:\tvalue")
+	if len(nodes) != 1 || len(nodes[0].children) != 1 || nodes[0].children[0].content != "Name:\tvalue" {
+		t.Errorf("Expected the tab in the text to survive, got %+v", nodes)
+	}
+}
+
+func TestEditorDeletionsGoToTrash(t *testing.T) {
+	project := NewBullet("Project")
+	obsolete := NewBullet("Obsolete")
+	keep := NewBullet("Keep")
+	stale := NewBullet("Stale")
+	obsolete.AddChild(keep)
+	obsolete.AddChild(stale)
+	project.AddChild(obsolete)
+	m := newTestModel(project)
+
+	// Delete "Obsolete" and "Stale" but move "Keep" up to the project
+	lines := strings.Split(strings.TrimSpace(formatSubtree(project)), "\n")
+	edited := lines[0] + "\n  " + strings.TrimSpace(lines[2]) + "\n"
+	path := filepath.Join(t.TempDir(), "edit.txt")
+	if err := os.WriteFile(path, []byte(edited), 0600); err != nil {
+		t.Fatal(err)
+	}
+	m.applyEditorResult(editorFinishedMsg{bulletID: project.ID, path: path})
+
+	if len(project.Children) != 1 || project.Children[0] != keep || keep.Parent != project {
+		t.Fatal("Expected Keep to be the only child of Project")
+	}
+	if len(m.trash) != 1 {
+		t.Fatalf("Expected one trash entry, got %d", len(m.trash))
+	}
+	entry := m.trash[0]
+	if entry.Bullet != obsolete || entry.ParentID != project.ID || entry.Index != 0 {
+		t.Errorf("Unexpected trash entry %+v", entry)
+	}
+	if len(obsolete.Children) != 1 || obsolete.Children[0] != stale {
+		t.Error("Expected only Stale to stay with Obsolete in the trash")
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// filterState narrows the outline to matching bullets and their ancestors.
+// The view ignores collapse state instead of changing it, and folds changed
+// while filtering are put back, so clearing the filter brings back the
+// outline exactly as it was folded.
+type filterState struct {
+	input     textinput.Model
+	query     string           // Lower-cased
+	keep      map[*Bullet]bool // Added or edited while filtering; shown even if they no longer match
+	collapsed map[string]bool  // Collapse state by bullet ID when the filter was opened
+}
+
+// openFilter shows the filter prompt in place of the help line, starting
+// from the current filter if there is one.
+func (m *Model) openFilter() tea.Cmd {
+	input := textinput.New()
+	input.Prompt = "filter: "
+	input.Placeholder = "type to narrow"
+	input.Focus()
+
+	if m.filter == nil {
+		m.filter = &filterState{keep: make(map[*Bullet]bool), collapsed: make(map[string]bool)}
+		walkBullets(m.rootBullets, func(b *Bullet) {
+			m.filter.collapsed[b.ID] = b.Collapsed
+		})
+	}
+	input.SetValue(m.filter.input.Value())
+	m.filter.input = input
+	m.editMode = EditModeFilter
+	return textinput.Blink
+}
+
+// filterActive reports whether the view is narrowed.
+func (m Model) filterActive() bool {
+	return m.filter != nil && m.filter.query != ""
+}
+
+// clearFilter goes back to the full outline, keeping the selection. Bullets
+// that existed when the filter was opened get their collapse state back,
+// whatever was expanded while filtering.
+func (m *Model) clearFilter() {
+	selected := m.getSelectedBullet()
+	walkBullets(m.rootBullets, func(b *Bullet) {
+		if collapsed, ok := m.filter.collapsed[b.ID]; ok {
+			b.Collapsed = collapsed
+		}
+	})
+	m.filter = nil
+	m.rebuildVisibleList()
+	m.selectBullet(selected)
+}
+
+// keepInFilter keeps bullets created or edited in the filtered view visible,
+// so they don't vanish while they are being worked on.
+func (m *Model) keepInFilter(bullets ...*Bullet) {
+	if !m.filterActive() {
+		return
+	}
+	for _, b := range bullets {
+		m.filter.keep[b] = true
+	}
+}
+
+// filterMatch reports whether b's content or note contains the filter query.
+func (m Model) filterMatch(b *Bullet) bool {
+	return strings.Contains(strings.ToLower(b.Content), m.filter.query) ||
+		strings.Contains(strings.ToLower(b.Note), m.filter.query) ||
+		m.filter.keep[b]
+}
+
+// filteredBullets lists the matching bullets below the zoom level, each
+// preceded by the ancestors that lead to it, in outline order.
+func (m Model) filteredBullets() []*Bullet {
+	var visible []*Bullet
+	var walk func(bullets []*Bullet) bool
+	walk = func(bullets []*Bullet) bool {
+		found := false
+		for _, b := range bullets {
+			position := len(visible)
+			visible = append(visible, b)
+			if !walk(b.Children) && !m.filterMatch(b) {
+				// Neither b nor anything below it matches
+				visible = visible[:position]
+				continue
+			}
+			found = true
+		}
+		return found
+	}
+
+	if m.zoomedBullet != nil {
+		// The zoomed bullet heads the view whether it matches or not
+		visible = append(visible, m.zoomedBullet)
+		walk(m.zoomedBullet.Children)
+	} else {
+		walk(m.rootBullets)
+	}
+	return visible
+}
+
+// updateFilter handles keys while the filter prompt is open. The view
+// narrows as you type; Enter keeps the filter, Esc clears it.
+func (m Model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := m.filter
+	switch msg.String() {
+	case "esc":
+		m.editMode = EditModeNone
+		m.clearFilter()
+		return m, nil
+
+	case "enter":
+		m.editMode = EditModeNone
+		f.input.Blur()
+		if f.query == "" {
+			m.clearFilter()
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	f.input, cmd = f.input.Update(msg)
+	if query := strings.ToLower(f.input.Value()); query != f.query {
+		selected := m.getSelectedBullet()
+		f.query = query
+		f.keep = make(map[*Bullet]bool)
+		m.rebuildVisibleList()
+		m.selectedIndex = 0
+		m.selectBullet(selected)
+	}
+	return m, cmd
+}
+
+// filterLine replaces the help line while the view is filtered.
+func (m Model) filterLine() string {
+	f := m.filter
+	count := 0
+	for _, b := range m.allBullets {
+		if f.query != "" && m.filterMatch(b) {
+			count++
+		}
+	}
+	if m.editMode == EditModeFilter {
+		return fmt.Sprintf("%s • %d shown • enter to keep • esc to clear", f.input.View(), count)
+	}
+	return fmt.Sprintf("filter: %s • %d shown • f to change • F to clear", f.input.Value(), count)
+}
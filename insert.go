@@ -0,0 +1,160 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// insertPosition says where a new bullet goes relative to the selection.
+type insertPosition int
+
+const (
+	insertBelow insertPosition = iota
+	insertAbove
+	insertFirstChild
+	insertLastChild
+)
+
+// pendingInsert is a new bullet being typed inline. It is shown at its place
+// in the tree but only added to the tree once committed, so an abandoned
+// insert never reaches the data file or the undo history.
+type pendingInsert struct {
+	bullet   *Bullet // Parent is set, but the parent does not list it yet
+	index    int     // Position among the parent's children, or the roots
+	returnTo *Bullet // Selected again when the insert is cancelled
+}
+
+// startInsert opens an inline editor for a new bullet next to or inside the
+// selected one. Above and below the zoomed bullet mean its first and last child.
+func (m *Model) startInsert(position insertPosition) tea.Cmd {
+	selected := m.getSelectedBullet()
+	var parent *Bullet
+	index := len(m.rootBullets)
+	switch {
+	case selected == nil:
+		if m.zoomedBullet != nil {
+			parent = m.zoomedBullet
+			index = len(parent.Children)
+		}
+	case position == insertFirstChild, position == insertAbove && selected == m.zoomedBullet:
+		parent = selected
+		index = 0
+	case position == insertLastChild, selected == m.zoomedBullet:
+		parent = selected
+		index = len(selected.Children)
+	case position == insertAbove:
+		parent = selected.Parent
+		index = indexOf(m.siblingsOf(selected), selected)
+	default:
+		parent = selected.Parent
+		index = indexOf(m.siblingsOf(selected), selected) + 1
+	}
+
+	if parent != nil {
+		parent.Collapsed = false
+	}
+	return m.editNewBullet(parent, index)
+}
+
+// editNewBullet shows an empty bullet at index among parent's children and
+// starts editing it.
+func (m *Model) editNewBullet(parent *Bullet, index int) tea.Cmd {
+	b := NewBullet("")
+	b.Parent = parent
+	m.pending = &pendingInsert{bullet: b, index: index, returnTo: m.getSelectedBullet()}
+
+	m.rebuildVisibleList()
+	m.selectBullet(b)
+	m.startEdit(b, 0)
+	m.editMode = EditModeNew
+	return textinput.Blink
+}
+
+// commitInsert adds the pending bullet to the tree and, for rapid entry,
+// continues with a new sibling right after it.
+func (m *Model) commitInsert(content string) tea.Cmd {
+	b := m.pending.bullet
+	parent := b.Parent
+	index := m.pending.index
+	m.recordUndo()
+	if returnTo := m.pending.returnTo; returnTo != nil {
+		// Undo goes back to where the insert started
+		m.undoStack[len(m.undoStack)-1].SelectedID = returnTo.ID
+	}
+
+	m.pending = nil
+	b.Content = content
+	b.IsEditing = false
+	m.keepInFilter(b)
+	m.insertAt(parent, clampIndex(index, m.childrenOf(parent)), []*Bullet{b})
+
+	// Auto-save after adding new bullet
+	m.saveData()
+
+	return m.editNewBullet(parent, indexOf(m.childrenOf(parent), b)+1)
+}
+
+// cancelInsert drops the pending bullet and returns to the bullet that was
+// selected before it.
+func (m *Model) cancelInsert() {
+	returnTo := m.pending.returnTo
+	m.pending = nil
+	m.rebuildVisibleList()
+	if returnTo != nil {
+		m.selectBullet(returnTo)
+	}
+	m.clampSelection()
+}
+
+// showPending places the pending bullet in the visible list, after its
+// previous sibling's shown descendants or right after its parent.
+func (m *Model) showPending() {
+	if m.pending == nil {
+		return
+	}
+	b := m.pending.bullet
+	siblings := m.childrenOf(b.Parent)
+	index := clampIndex(m.pending.index, siblings)
+
+	// Siblings may be hidden by the filter; follow the nearest shown one
+	for index > 0 && indexOf(m.allBullets, siblings[index-1]) < 0 {
+		index--
+	}
+
+	position := 0
+	if index > 0 {
+		prev := siblings[index-1]
+		if position = indexOf(m.allBullets, prev); position < 0 {
+			return
+		}
+		// Skip past whatever is shown of prev's subtree
+		position++
+		for position < len(m.allBullets) && isAncestorOrSelf(prev, m.allBullets[position]) {
+			position++
+		}
+	} else if b.Parent != nil {
+		if position = indexOf(m.allBullets, b.Parent); position < 0 {
+			return
+		}
+		position++
+	}
+
+	m.allBullets = append(m.allBullets[:position], append([]*Bullet{b}, m.allBullets[position:]...)...)
+}
+
+// pendingBullet returns the bullet being typed inline, if any.
+func (m *Model) pendingBullet() *Bullet {
+	if m.pending == nil {
+		return nil
+	}
+	return m.pending.bullet
+}
+
+// clampIndex keeps an insert position valid if the list changed meanwhile,
+// e.g. through a remote command.
+func clampIndex(index int, bullets []*Bullet) int {
+	if index > len(bullets) {
+		return len(bullets)
+	}
+	return index
+}
@@ -0,0 +1,63 @@
+package main
+
+// maxJumps caps the jump list in each direction.
+const maxJumps = 100
+
+// location is a place in the jump list. Bullets are kept by ID, so entries
+// survive undo, which replaces the bullets with copies.
+type location struct {
+	zoomedID   string
+	selectedID string
+}
+
+// here returns the current location.
+func (m *Model) here() location {
+	var loc location
+	if m.zoomedBullet != nil {
+		loc.zoomedID = m.zoomedBullet.ID
+	}
+	if selected := m.getSelectedBullet(); selected != nil && selected != m.pendingBullet() {
+		loc.selectedID = selected.ID
+	}
+	return loc
+}
+
+// pushJump records the current location before the zoom changes.
+func (m *Model) pushJump() {
+	m.jumpBack = append(m.jumpBack, m.here())
+	if len(m.jumpBack) > maxJumps {
+		m.jumpBack = m.jumpBack[len(m.jumpBack)-maxJumps:]
+	}
+	m.jumpForward = nil
+}
+
+// jumpHistory goes back to the previous location, or forward again with
+// forward set. Locations whose zoomed bullet has been deleted are skipped.
+func (m *Model) jumpHistory(forward bool) {
+	from, to := &m.jumpBack, &m.jumpForward
+	if forward {
+		from, to = to, from
+	}
+
+	for len(*from) > 0 {
+		loc := (*from)[len(*from)-1]
+		*from = (*from)[:len(*from)-1]
+
+		zoomed := findBulletByID(m.rootBullets, loc.zoomedID)
+		if zoomed == nil && loc.zoomedID != "" {
+			continue
+		}
+		*to = append(*to, m.here())
+		m.zoomTo(zoomed)
+		if selected := findBulletByID(m.rootBullets, loc.selectedID); selected != nil {
+			m.selectBullet(selected)
+		}
+		return
+	}
+
+	if forward {
+		m.statusMessage = "No newer location"
+	} else {
+		m.statusMessage = "No earlier location"
+	}
+}
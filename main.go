@@ -11,8 +11,30 @@ import (
 const Version = "1.1.0"
 
 func main() {
+	// Subcommands are dispatched before the top-level flags are parsed
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "ctl":
+			exitOnError(runCtl(os.Args[2:]))
+			return
+		case "doctor":
+			exitOnError(runDoctor(os.Args[2:]))
+			return
+		case "stats":
+			exitOnError(runStats(os.Args[2:]))
+			return
+		case "completion":
+			exitOnError(runCompletion(os.Args[2:]))
+			return
+		case "__complete":
+			exitOnError(runComplete(os.Args[2:]))
+			return
+		}
+	}
+
 	var showVersion = flag.Bool("version", false, "Show version information")
 	var showHelp = flag.Bool("help", false, "Show help information")
+	var readOnly = flag.Bool("readonly", false, "Open the outline without allowing changes")
 	flag.Parse()
 
 	if *showVersion {
@@ -25,9 +47,14 @@ func main() {
 		fmt.Println("OCLI - Terminal Outliner")
 		fmt.Printf("Version: %s\n\n", Version)
 		fmt.Println("Usage: ocli [options]")
+		fmt.Println("       ocli ctl <command>   Control a running OCLI (see 'ocli ctl help')")
+		fmt.Println("       ocli doctor [--fix]  Check the data file for problems and repair them")
+		fmt.Println("       ocli stats [--json] [--id ID]  Show outline statistics")
+		fmt.Println("       ocli completion bash|zsh|fish  Print a shell completion script")
 		fmt.Println("\nOptions:")
 		fmt.Println("  --version    Show version information")
 		fmt.Println("  --help       Show this help message")
+		fmt.Println("  --readonly   View the outline without allowing changes")
 		fmt.Println("\nKeyboard shortcuts available in the app:")
 		fmt.Println("  h            Show interactive help screen")
 		fmt.Println("  s            Show settings")
@@ -36,8 +63,33 @@ func main() {
 		return
 	}
 
-	p := tea.NewProgram(NewModel(), tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	model := NewModel()
+	if *readOnly {
+		model.readOnly = true
+	}
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+
+	// Accept `ocli ctl` commands while the TUI is running. A read-only
+	// instance has nothing to offer them, so it leaves the socket to others.
+	var remote *RemoteServer
+	if model.configManager != nil && !model.readOnly {
+		var err error
+		remote, err = StartRemoteServer(model.configManager.SocketPath(), p.Send)
+		if err != nil {
+			// Send blocks until the program is running
+			go p.Send(remoteUnavailableMsg{err: err})
+		}
+	}
+
+	_, err := p.Run()
+	if remote != nil {
+		remote.Close()
+	}
+	exitOnError(err)
+}
+
+func exitOnError(err error) {
+	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,989 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// newTestModel builds a model around the given tree without touching the user's config.
+func newTestModel(roots ...*Bullet) Model {
+	m := Model{
+		rootBullets: roots,
+		textInput:   textinput.New(),
+		noteInput:   newNoteInput(),
+		breadcrumbs: make([]*Bullet, 0),
+		width:       80,
+		height:      40,
+	}
+	m.rebuildVisibleList()
+	return m
+}
+
+// keyMsg builds the tea.KeyMsg for a key name as reported by msg.String().
+func keyMsg(key string) tea.KeyMsg {
+	special := map[string]tea.KeyType{
+		"enter":      tea.KeyEnter,
+		"esc":        tea.KeyEsc,
+		"tab":        tea.KeyTab,
+		"shift+tab":  tea.KeyShiftTab,
+		"up":         tea.KeyUp,
+		"down":       tea.KeyDown,
+		"left":       tea.KeyLeft,
+		"right":      tea.KeyRight,
+		"home":       tea.KeyHome,
+		"shift+up":   tea.KeyShiftUp,
+		"shift+down": tea.KeyShiftDown,
+		"backspace":  tea.KeyBackspace,
+		" ":          tea.KeySpace,
+		"ctrl+r":     tea.KeyCtrlR,
+		"ctrl+s":     tea.KeyCtrlS,
+		"ctrl+p":     tea.KeyCtrlP,
+		"ctrl+o":     tea.KeyCtrlO,
+	}
+	if keyType, ok := special[key]; ok {
+		return tea.KeyMsg{Type: keyType}
+	}
+	if strings.HasPrefix(key, "alt+") {
+		if keyType, ok := special[key[len("alt+"):]]; ok {
+			return tea.KeyMsg{Type: keyType, Alt: true}
+		}
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key[len("alt+"):]), Alt: true}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// pressKeys feeds the keys through Update in order.
+func pressKeys(m Model, keys ...string) Model {
+	for _, key := range keys {
+		updated, _ := m.Update(keyMsg(key))
+		m = updated.(Model)
+	}
+	return m
+}
+
+func TestReadOnlyRejectsChanges(t *testing.T) {
+	root := NewBullet("Root")
+	child := NewBullet("Child")
+	root.AddChild(child)
+	sibling := NewBullet("Sibling")
+	m := newTestModel(root, sibling)
+	m.readOnly = true
+
+	m = pressKeys(m, "down", "d", "shift+tab", "c", "t", "e", "enter")
+	if len(root.Children) != 1 || child.Parent != root || child.Color != ColorDefault || child.IsTask {
+		t.Error("Read-only model modified the outline")
+	}
+	if m.editMode != EditModeNone {
+		t.Error("Read-only model entered edit mode")
+	}
+	if m.statusMessage == "" {
+		t.Error("Expected a status message explaining the rejection")
+	}
+
+	// Navigation and collapsing still work
+	m = pressKeys(m, "up", " ")
+	if !root.Collapsed || len(m.allBullets) != 2 {
+		t.Error("Expected collapse to work in read-only mode")
+	}
+	m = pressKeys(m, "z", "O")
+	if root.Collapsed || m.pendingKey != "" {
+		t.Error("Expected z commands to work in read-only mode")
+	}
+	m = pressKeys(m, "z", "M")
+	if !root.Collapsed || m.statusMessage != "" {
+		t.Errorf("Expected zM to fold in read-only mode, got %q", m.statusMessage)
+	}
+
+	m = pressKeys(m, "down", "right")
+	if m.zoomedBullet != sibling {
+		t.Error("Expected zoom to work in read-only mode")
+	}
+}
+
+func TestNoOpIndentKeepsUndoHistory(t *testing.T) {
+	parent := NewBullet("Parent")
+	child := NewBullet("Child")
+	parent.AddChild(child)
+	m := newTestModel(parent)
+
+	// Recolor and undo, then indent a first child, which cannot move
+	m = pressKeys(m, "c", "u", "down", "tab")
+	if child.Parent != parent || len(m.undoStack) != 0 {
+		t.Fatalf("Expected a no-op indent without an undo entry, got %d entries", len(m.undoStack))
+	}
+
+	m = pressKeys(m, "ctrl+r")
+	if m.rootBullets[0].Color != ColorBlue {
+		t.Error("Expected the redo history to survive a no-op indent")
+	}
+}
+
+func TestUndoRedo(t *testing.T) {
+	project := NewBullet("Project")
+	project.AddChild(NewBullet("Design"))
+	project.AddChild(NewBullet("Build"))
+	other := NewBullet("Other")
+	m := newTestModel(project, other)
+
+	// Zoom into the project, delete "Build", recolor "Design"
+	m = pressKeys(m, "right", "down", "down", "d", "c")
+	if len(m.zoomedBullet.Children) != 1 || m.zoomedBullet.Children[0].Color != ColorBlue {
+		t.Fatal("Expected delete and recolor to apply")
+	}
+
+	m = pressKeys(m, "u")
+	if m.zoomedBullet.Children[0].Color != ColorDefault {
+		t.Error("Expected undo to revert the color change")
+	}
+
+	m = pressKeys(m, "u")
+	children := m.zoomedBullet.Children
+	if len(children) != 2 || children[1].Content != "Build" || children[1].Parent != m.zoomedBullet {
+		t.Fatal("Expected undo to restore the deleted bullet in place")
+	}
+	if m.zoomedBullet.Content != "Project" || m.getSelectedBullet().Content != "Build" {
+		t.Errorf("Expected zoom and selection to be restored, got %q / %q",
+			m.zoomedBullet.Content, m.getSelectedBullet().Content)
+	}
+
+	m = pressKeys(m, "u")
+	if m.statusMessage != "Nothing to undo" {
+		t.Errorf("Expected empty undo history, got %q", m.statusMessage)
+	}
+
+	m = pressKeys(m, "ctrl+r")
+	if len(m.zoomedBullet.Children) != 1 {
+		t.Error("Expected redo to delete the bullet again")
+	}
+
+	// A new change clears the redo history
+	m = pressKeys(m, "t", "ctrl+r")
+	if m.statusMessage != "Nothing to redo" {
+		t.Errorf("Expected redo history to be cleared, got %q", m.statusMessage)
+	}
+}
+
+func TestNotes(t *testing.T) {
+	first := NewBullet("First")
+	second := NewBullet("Second")
+	m := newTestModel(first, second)
+
+	m = pressKeys(m, "a", "Line one", "enter", "Line two", "ctrl+s")
+	if first.Note != "Line one\nLine two" {
+		t.Fatalf("Expected multi-line note, got %q", first.Note)
+	}
+	if m.editMode != EditModeNone || first.IsEditing {
+		t.Error("Expected note editor to close after saving")
+	}
+
+	// The note is shown under the selected bullet only
+	if lines := m.renderBullet(0); len(lines) != 3 {
+		t.Errorf("Expected bullet plus two note lines, got %d lines", len(lines))
+	}
+	m = pressKeys(m, "down")
+	if lines := m.renderBullet(0); len(lines) != 1 {
+		t.Errorf("Expected note to be hidden when not selected, got %d lines", len(lines))
+	}
+
+	// Cancelling leaves the note untouched
+	m = pressKeys(m, "up", "a", "changed", "esc")
+	if first.Note != "Line one\nLine two" {
+		t.Errorf("Expected cancelled edit to keep the note, got %q", first.Note)
+	}
+
+	m = pressKeys(m, "u")
+	if m.rootBullets[0].Note != "" {
+		t.Errorf("Expected undo to remove the note, got %q", m.rootBullets[0].Note)
+	}
+}
+
+func TestLongBulletsWrap(t *testing.T) {
+	if lines := wrapText("alpha beta gamma", 11); len(lines) != 2 || lines[0] != "alpha beta" || lines[1] != "gamma" {
+		t.Errorf("Unexpected word wrap: %q", lines)
+	}
+	if lines := wrapText("abcdefghij", 4); len(lines) != 3 || lines[2] != "ij" {
+		t.Errorf("Expected long word to be broken, got %q", lines)
+	}
+
+	long := NewBullet(strings.Repeat("word ", 100))
+	m := newTestModel(NewBullet("Before"), long)
+	m.width = 40
+	m.height = 12
+	m.rebuildVisibleList()
+
+	lines := m.renderBullet(1)
+	if len(lines) < 2 {
+		t.Fatalf("Expected long bullet to wrap, got %d line", len(lines))
+	}
+	for _, line := range lines {
+		if width := lipgloss.Width(line); width > m.width-2 {
+			t.Errorf("Line is %d cells wide, wider than the screen: %q", width, line)
+		}
+	}
+
+	// Selecting the long bullet scrolls by rendered lines
+	m = pressKeys(m, "down")
+	if m.scrollOffset != 1 {
+		t.Errorf("Expected the view to scroll past the first bullet, got offset %d", m.scrollOffset)
+	}
+
+}
+
+func TestMarkersFitWidth(t *testing.T) {
+	// Every length fills the last line differently, including exactly
+	for n := 1; n < 80; n++ {
+		noted := NewBullet(strings.Repeat("x", n) + " " + strings.Repeat("y", 30))
+		noted.Note = "A note"
+		m := newTestModel(NewBullet("Before"), noted)
+		m.width = 40
+		m.bookmarks = []string{noted.ID}
+		m.rebuildVisibleList()
+
+		for _, line := range m.renderBullet(1) {
+			if width := lipgloss.Width(line); width > m.width-2 {
+				t.Fatalf("Line is %d cells wide, wider than the screen: %q", width, line)
+			}
+		}
+	}
+}
+
+func TestVisualRangeOperations(t *testing.T) {
+	first := NewBullet("First")
+	second := NewBullet("Second")
+	child := NewBullet("Child")
+	second.AddChild(child)
+	third := NewBullet("Third")
+	m := newTestModel(first, second, third)
+
+	// Select Second (with its child) and Third, then indent them under First
+	m = pressKeys(m, "down", "V", "down", "down")
+	if len(m.rangeRoots()) != 2 {
+		t.Fatalf("Expected 2 selected roots, got %d", len(m.rangeRoots()))
+	}
+	m = pressKeys(m, "tab")
+	if len(m.rootBullets) != 1 || len(first.Children) != 2 || first.Children[1] != third {
+		t.Fatalf("Expected Second and Third under First, got %d roots", len(m.rootBullets))
+	}
+	if child.Parent != second {
+		t.Error("Expected Child to stay under Second")
+	}
+
+	// Bulk formatting applies to every selected bullet
+	m = pressKeys(m, "t", "x")
+	for _, b := range []*Bullet{second, child, third} {
+		if !b.IsTask || !b.Completed {
+			t.Errorf("Expected %q to be a completed task", b.Content)
+		}
+	}
+	if first.IsTask {
+		t.Error("Expected First to be outside the selection")
+	}
+
+	// Outdent the block back to the top level, then move it above First
+	m = pressKeys(m, "shift+tab")
+	if len(m.rootBullets) != 3 || m.rootBullets[1] != second || m.rootBullets[2] != third {
+		t.Fatalf("Expected outdent to restore the order, got %d roots", len(m.rootBullets))
+	}
+	m = pressKeys(m, "shift+up")
+	if m.rootBullets[0] != second || m.rootBullets[1] != third || m.rootBullets[2] != first {
+		t.Errorf("Expected the block to move above First")
+	}
+
+	// Deleting removes the whole selection in one undo step
+	m = pressKeys(m, "d")
+	if len(m.rootBullets) != 1 || m.visualAnchor != nil {
+		t.Fatalf("Expected only First to remain and visual mode to end")
+	}
+	m = pressKeys(m, "u")
+	if len(m.rootBullets) != 3 {
+		t.Errorf("Expected undo to restore the deleted range, got %d roots", len(m.rootBullets))
+	}
+}
+
+func TestRegisters(t *testing.T) {
+	project := NewBullet("Project")
+	task := NewBullet("Task")
+	project.AddChild(task)
+	inbox := NewBullet("Inbox")
+	m := newTestModel(project, inbox)
+
+	// Cut and put is a move that keeps IDs
+	m = pressKeys(m, "down", "D")
+	if len(project.Children) != 0 {
+		t.Fatal("Expected cut to remove Task")
+	}
+	m = pressKeys(m, "down", "P")
+	if len(inbox.Children) != 1 || inbox.Children[0].ID != task.ID {
+		t.Fatalf("Expected Task to be put under Inbox with its ID")
+	}
+	if inbox.Children[0].Parent != inbox {
+		t.Error("Expected put bullet to be linked to its parent")
+	}
+	if len(m.trash) != 0 {
+		t.Errorf("Expected cut not to add to the trash, got %d entries", len(m.trash))
+	}
+
+	// A second put is a copy with fresh IDs
+	m = pressKeys(m, "p")
+	if len(inbox.Children) != 2 || inbox.Children[1].ID == task.ID || inbox.Children[1].Content != "Task" {
+		t.Fatal("Expected a copy of Task with a new ID")
+	}
+
+	// Yank Inbox, zoom into it and put the copy inside
+	m = pressKeys(m, "up", "up", "y", "right", "p")
+	if len(inbox.Children) != 3 || len(inbox.Children[2].Children) != 2 {
+		t.Fatalf("Expected Inbox copy to be put inside the zoomed Inbox")
+	}
+	if m.statusMessage != `Put "Inbox" +2` {
+		t.Errorf("Unexpected status message %q", m.statusMessage)
+	}
+}
+
+func TestSplitAndJoin(t *testing.T) {
+	first := NewBullet("Buy milk and eggs")
+	first.ToggleTask()
+	first.Color = ColorRed
+	child := NewBullet("Child")
+	first.AddChild(child)
+	m := newTestModel(first)
+
+	// Enter in the middle of the text splits at the cursor
+	m = pressKeys(m, "e", "left", "left", "left", "left", "left", "left", "left", "left", "left", "enter")
+	if len(m.rootBullets) != 2 {
+		t.Fatalf("Expected split into two bullets, got %d", len(m.rootBullets))
+	}
+	second := m.rootBullets[1]
+	if first.Content != "Buy milk" || second.Content != "and eggs" {
+		t.Errorf("Unexpected split contents %q and %q", first.Content, second.Content)
+	}
+	if len(first.Children) != 1 || len(second.Children) != 0 {
+		t.Error("Expected children to stay with the first bullet")
+	}
+	if !second.IsTask || second.Color != ColorRed {
+		t.Error("Expected the new bullet to keep task type and color")
+	}
+	if m.editingBullet != second || m.textInput.Position() != 0 {
+		t.Fatal("Expected to continue editing the new bullet at its start")
+	}
+
+	// Backspace at the start joins onto the previous visible bullet (Child)
+	m = pressKeys(m, "backspace")
+	if len(m.rootBullets) != 1 || child.Content != "Childand eggs" {
+		t.Fatalf("Expected join into Child, got %q", child.Content)
+	}
+	if m.editingBullet != child || m.textInput.Position() != len("Child") {
+		t.Error("Expected cursor at the join point")
+	}
+
+	// Joining a first child into its parent keeps grandchildren in place
+	m = pressKeys(m, "esc")
+	grandchild := NewBullet("Grandchild")
+	child.AddChild(grandchild)
+	m.rebuildVisibleList()
+	m = pressKeys(m, "e", "home", "backspace")
+	if len(first.Children) != 1 || first.Children[0] != grandchild || first.Content != "Buy milkChildand eggs" {
+		t.Errorf("Expected Child to merge into its parent, got %q with %d children", first.Content, len(first.Children))
+	}
+
+	m = pressKeys(m, "esc", "u")
+	if len(m.rootBullets[0].Children) != 1 || m.rootBullets[0].Children[0].Content != "Childand eggs" {
+		t.Error("Expected undo to restore the joined bullet")
+	}
+}
+
+func TestInlineInsert(t *testing.T) {
+	parent := NewBullet("Parent")
+	existing := NewBullet("Existing")
+	parent.AddChild(existing)
+	m := newTestModel(parent)
+
+	// The new bullet is shown in place before it is added to the tree
+	m = pressKeys(m, "i")
+	if m.editMode != EditModeNew || m.allBullets[1] != m.editingBullet || len(parent.Children) != 1 {
+		t.Fatal("Expected a pending first child shown below Parent")
+	}
+
+	// Rapid entry adds siblings one after another
+	m = pressKeys(m, "One", "enter", "Two", "enter", "enter")
+	if len(parent.Children) != 3 || parent.Children[0].Content != "One" || parent.Children[1].Content != "Two" {
+		t.Fatalf("Expected One and Two before Existing, got %d children", len(parent.Children))
+	}
+	if m.editMode != EditModeNone || m.getSelectedBullet() != parent.Children[1] {
+		t.Error("Expected Enter on an empty bullet to stop on the last added bullet")
+	}
+
+	m = pressKeys(m, "O", "Zero", "enter", "esc")
+	if parent.Children[1].Content != "Zero" || len(parent.Children) != 4 {
+		t.Errorf("Expected Zero above Two, got %q", parent.Children[1].Content)
+	}
+
+	m = pressKeys(m, "up", "up", "up", "I", "Last", "esc")
+	if len(parent.Children) != 4 {
+		t.Error("Expected Esc to discard the pending bullet")
+	}
+	if m.getSelectedBullet() != parent {
+		t.Error("Expected Esc to return to the bullet the insert started from")
+	}
+
+	// Each added bullet is its own undo step
+	m = pressKeys(m, "u")
+	if children := m.rootBullets[0].Children; len(children) != 3 || children[1].Content != "Two" {
+		t.Errorf("Expected undo to remove Zero, got %d children", len(children))
+	}
+}
+
+func TestTrashAndArchive(t *testing.T) {
+	project := NewBullet("Project")
+	done := NewBullet("Done")
+	done.ToggleTask()
+	done.ToggleComplete()
+	open := NewBullet("Open")
+	open.ToggleTask()
+	project.AddChild(done)
+	project.AddChild(open)
+	m := newTestModel(project)
+
+	// Deleting moves the branch to the trash with its place recorded
+	m = pressKeys(m, "down", "d")
+	if len(project.Children) != 1 || len(m.trash) != 1 {
+		t.Fatalf("Expected Done in the trash, got %d children and %d trash entries", len(project.Children), len(m.trash))
+	}
+	if entry := m.trash[0]; entry.ParentID != project.ID || entry.Index != 0 || entry.Path != "Project" {
+		t.Errorf("Unexpected trash entry %+v", entry)
+	}
+
+	// Restoring from the trash view puts it back where it was
+	m = pressKeys(m, "T", "enter", "esc")
+	if len(m.trash) != 0 || len(project.Children) != 2 || project.Children[0] != done {
+		t.Fatal("Expected Done restored as the first child")
+	}
+
+	// Branches with open tasks are not archived
+	m = pressKeys(m, "up", "A")
+	if len(m.archive) != 0 {
+		t.Error("Expected a branch with an open task to stay")
+	}
+	m = pressKeys(m, "down", "A")
+	if len(m.archive) != 1 || len(project.Children) != 1 {
+		t.Fatal("Expected Done to be archived")
+	}
+
+	// Archived bullets remain searchable
+	response := m.handleRemoteCommand(RemoteCommand{Action: "search", Query: "done"})
+	if len(response.Matches) != 1 || response.Matches[0].Path != "Archive > Done" {
+		t.Errorf("Expected archive search match, got %+v", response.Matches)
+	}
+	m = pressKeys(m, "/", "done", "enter")
+	if line := m.searchLine(); !strings.Contains(line, "+1 archived") {
+		t.Errorf("Expected the search line to count the archived match, got %q", line)
+	}
+	m = pressKeys(m, "esc")
+
+	// Undo brings the archived branch back and empties the archive
+	m = pressKeys(m, "u")
+	if len(m.archive) != 0 || len(m.rootBullets[0].Children) != 2 {
+		t.Error("Expected undo to revert the archive")
+	}
+}
+
+func TestRestoreTopLevelBullet(t *testing.T) {
+	a, b, c := NewBullet("A"), NewBullet("B"), NewBullet("C")
+	m := newTestModel(a, b, c)
+
+	m = pressKeys(m, "d", "T", "enter")
+	if m.statusMessage != `Restored "A"` {
+		t.Errorf("Unexpected status message %q", m.statusMessage)
+	}
+	if len(m.rootBullets) != 3 || m.rootBullets[0] != a || m.rootBullets[1] != b || m.rootBullets[2] != c {
+		t.Fatalf("Expected A restored as the first bullet, got %q %q %q",
+			m.rootBullets[0].Content, m.rootBullets[1].Content, m.rootBullets[2].Content)
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	m := newTestModel()
+	now := time.Now()
+	m.trash = []*RemovedBullet{
+		{Bullet: NewBullet("Recent"), RemovedAt: now.AddDate(0, 0, -1)},
+		{Bullet: NewBullet("Old"), RemovedAt: now.AddDate(0, 0, -40)},
+	}
+
+	m.purgeTrash(now)
+	if len(m.trash) != 2 {
+		t.Error("Expected no purge when retention is unset")
+	}
+
+	m.settings.TrashRetentionDays = 30
+	m.purgeTrash(now)
+	if len(m.trash) != 1 || m.trash[0].Bullet.Content != "Recent" {
+		t.Errorf("Expected only the recent entry to remain, got %d", len(m.trash))
+	}
+}
+
+func TestRefile(t *testing.T) {
+	work := NewBullet("Work")
+	plans := NewBullet("Plans")
+	work.AddChild(plans)
+	plans.AddChild(NewBullet("Q3"))
+	inbox := NewBullet("Inbox")
+	idea := NewBullet("Idea")
+	inbox.AddChild(idea)
+	m := newTestModel(work, inbox)
+
+	// Moving a bullet never offers itself or its descendants as targets
+	m = pressKeys(m, "m")
+	for _, candidate := range m.picker.candidates {
+		if candidate == work || candidate == plans {
+			t.Fatalf("Expected %q to be excluded from the targets", candidate.Content)
+		}
+	}
+	m = pressKeys(m, "esc")
+
+	// Fuzzy matching on the path finds the target
+	m = pressKeys(m, "down", "down", "down", "down", "m", "wrkpln")
+	if len(m.picker.matches) == 0 || m.picker.matches[0] != plans {
+		t.Fatalf("Expected Work > Plans as the best match, got %d matches", len(m.picker.matches))
+	}
+	m = pressKeys(m, "tab", "enter")
+	if idea.Parent != plans || plans.Children[0] != idea || len(inbox.Children) != 0 {
+		t.Fatal("Expected Idea to become the first child of Plans")
+	}
+
+	// Duplicating leaves the original and gives the copy new IDs
+	m = pressKeys(m, "M", "inbox", "enter")
+	if len(inbox.Children) != 1 || inbox.Children[0].Content != "Idea" || inbox.Children[0].ID == idea.ID {
+		t.Fatal("Expected a copy of Idea under Inbox")
+	}
+	if idea.Parent != plans {
+		t.Error("Expected the original to stay in place")
+	}
+
+	// The move itself also refuses cycles
+	m.picker = &picker{action: pickMoveTo, subjects: []*Bullet{work}}
+	m.refile(plans)
+	if m.statusMessage != "Cannot move a bullet into itself" || plans.Parent != work {
+		t.Errorf("Expected the move into a descendant to be refused, got %q", m.statusMessage)
+	}
+}
+
+func TestSearchHighlightsWrappedTextAndNotes(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	defer lipgloss.SetColorProfile(profile)
+	const marked = "48;5;220" // The highlight background
+
+	b := NewBullet("alpha beta gamma")
+	b.Note = "first line\nbeta gamma"
+	m := newTestModel(b)
+	m = pressKeys(m, "/", "beta gamma", "enter")
+
+	lines := m.highlightWrapped(b.Content, 11, lipgloss.NewStyle())
+	if len(lines) != 2 || !strings.Contains(lines[0], marked) || !strings.Contains(lines[1], marked) {
+		t.Errorf("Expected the match to be marked on both wrapped lines, got %q", lines)
+	}
+
+	notes := m.noteLines(b)
+	if len(notes) != 2 || strings.Contains(notes[0], marked) || !strings.Contains(notes[1], marked) {
+		t.Errorf("Expected the match in the note to be marked, got %q", notes)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	work := NewBullet("Work")
+	plans := NewBullet("Plans")
+	work.AddChild(plans)
+	budget := NewBullet("Budget 2024")
+	plans.AddChild(budget)
+	work.Collapsed = true
+	inbox := NewBullet("Inbox")
+	noted := NewBullet("Call Anna")
+	noted.Note = "about the budget"
+	inbox.AddChild(noted)
+	m := newTestModel(work, inbox)
+
+	// Typing only moves to matches that are already visible
+	m = pressKeys(m, "/", "budget")
+	if got := len(m.searchMatches()); got != 2 {
+		t.Fatalf("Expected matches in content and notes, got %d", got)
+	}
+	if m.getSelectedBullet() != noted {
+		t.Fatalf("Expected the visible match to be selected, got %q", m.getSelectedBullet().Content)
+	}
+
+	// n wraps around and expands the collapsed branch
+	m = pressKeys(m, "enter", "n")
+	if m.getSelectedBullet() != budget || work.Collapsed || plans.Collapsed {
+		t.Fatal("Expected n to reveal the match inside the collapsed branch")
+	}
+	m = pressKeys(m, "N")
+	if m.getSelectedBullet() != noted {
+		t.Fatal("Expected N to go back to the previous match")
+	}
+
+	// Matches outside the zoomed bullet zoom out as far as needed
+	m.zoomTo(inbox)
+	m = pressKeys(m, "N")
+	if m.zoomedBullet != nil || m.getSelectedBullet() != budget {
+		t.Fatal("Expected N to zoom out to reach the match")
+	}
+
+	// Case sensitivity and regular expressions
+	m = pressKeys(m, "/", "Budget", "alt+c")
+	if got := len(m.searchMatches()); got != 1 {
+		t.Errorf("Expected 1 case-sensitive match, got %d", got)
+	}
+	m = pressKeys(m, "alt+r", "backspace", "backspace", "backspace", "backspace", "backspace", "backspace", "[0-9]+$")
+	if got := m.searchMatches(); len(got) != 1 || got[0] != budget {
+		t.Errorf("Expected the regex to match only %q", budget.Content)
+	}
+
+	// Esc clears the highlight
+	m = pressKeys(m, "enter", "esc")
+	if m.search != nil {
+		t.Error("Expected esc to clear the search")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	work := NewBullet("Work")
+	plans := NewBullet("Plans")
+	work.AddChild(plans)
+	budget := NewBullet("Budget")
+	plans.AddChild(budget)
+	plans.AddChild(NewBullet("Hiring"))
+	work.Collapsed = true
+	inbox := NewBullet("Inbox")
+	noted := NewBullet("Call Anna")
+	noted.Note = "about the budget"
+	inbox.AddChild(noted)
+	other := NewBullet("Other")
+	m := newTestModel(work, inbox, other)
+
+	// Matches are shown with their ancestors, whatever the folding
+	m = pressKeys(m, "f", "budget", "enter")
+	want := []*Bullet{work, plans, budget, inbox, noted}
+	if len(m.allBullets) != len(want) {
+		t.Fatalf("Expected %d bullets in the filtered view, got %d", len(want), len(m.allBullets))
+	}
+	for i, b := range want {
+		if m.allBullets[i] != b {
+			t.Errorf("Expected %q at %d, got %q", b.Content, i, m.allBullets[i].Content)
+		}
+	}
+
+	// Normal operations work on the filtered view, and new bullets stay shown
+	m = pressKeys(m, "down", "down", "t", "o", "Forecast", "enter", "esc")
+	if !budget.IsTask {
+		t.Error("Expected t to apply to the selected match")
+	}
+	if len(plans.Children) != 3 || plans.Children[1].Content != "Forecast" {
+		t.Fatal("Expected the new bullet right after Budget")
+	}
+	if indexOf(m.allBullets, plans.Children[1]) != 3 {
+		t.Error("Expected the new bullet to stay in the filtered view")
+	}
+
+	// Folding while filtered does not outlive the filter
+	m = pressKeys(m, "up", "up", " ")
+	if !plans.Collapsed {
+		t.Fatal("Expected space to fold Plans while filtered")
+	}
+
+	// Clearing the filter leaves the folding untouched
+	m = pressKeys(m, "F")
+	if plans.Collapsed {
+		t.Error("Expected Plans to be expanded again after clearing the filter")
+	}
+	if m.filter != nil || !work.Collapsed {
+		t.Fatal("Expected F to clear the filter and keep Work collapsed")
+	}
+	if len(m.allBullets) != 4 {
+		t.Errorf("Expected the full outline back, got %d bullets", len(m.allBullets))
+	}
+}
+
+func TestQuickSwitcher(t *testing.T) {
+	projects := NewBullet("Projects")
+	q3 := NewBullet("Q3")
+	projects.AddChild(q3)
+	launch := NewBullet("Launch")
+	q3.AddChild(launch)
+	launch.AddChild(NewBullet("Press release"))
+	lunch := NewBullet("Lunch")
+	q3.AddChild(lunch)
+	m := newTestModel(projects, NewBullet("Inbox"))
+
+	// A bullet with children is zoomed into, with breadcrumbs up to it
+	m = pressKeys(m, "ctrl+p", "prjlaunch", "enter")
+	if m.zoomedBullet != launch || len(m.breadcrumbs) != 2 || m.breadcrumbs[1] != q3 {
+		t.Fatal("Expected to zoom into Projects > Q3 > Launch")
+	}
+
+	// A leaf is selected inside its zoomed parent
+	m = pressKeys(m, "ctrl+p", "lunch", "enter")
+	if m.zoomedBullet != q3 || m.getSelectedBullet() != lunch {
+		t.Fatal("Expected Lunch to be selected inside Q3")
+	}
+
+	// Recently visited bullets rank higher among similar matches
+	m = pressKeys(m, "ctrl+p", "l")
+	if m.picker.matches[0] != lunch {
+		t.Errorf("Expected the most recent visit first, got %q", m.picker.matches[0].Content)
+	}
+}
+
+func TestFolding(t *testing.T) {
+	work := NewBullet("Work")
+	plans := NewBullet("Plans")
+	work.AddChild(plans)
+	plans.AddChild(NewBullet("Q3"))
+	home := NewBullet("Home")
+	home.AddChild(NewBullet("Garden"))
+	m := newTestModel(work, home)
+
+	m = pressKeys(m, "down", "z", "M")
+	if !work.Collapsed || !plans.Collapsed || !home.Collapsed || len(m.allBullets) != 2 {
+		t.Fatal("Expected zM to collapse everything")
+	}
+	if m.getSelectedBullet() != work {
+		t.Error("Expected the selection to move to the nearest shown ancestor")
+	}
+
+	m = pressKeys(m, "z", "O")
+	if work.Collapsed || plans.Collapsed || !home.Collapsed {
+		t.Fatal("Expected zO to expand only the selected branch")
+	}
+
+	m = pressKeys(m, "z", "R", "z", "2")
+	if work.Collapsed || !plans.Collapsed || home.Collapsed || len(m.allBullets) != 4 {
+		t.Fatal("Expected z2 to show two levels")
+	}
+
+	m = pressKeys(m, "z", "R", "z", "C")
+	if work.Collapsed || !home.Collapsed {
+		t.Fatal("Expected zC to collapse the siblings of the selected bullet")
+	}
+
+	// When zoomed in, only the zoomed branch is folded
+	m = pressKeys(m, "right", "z", "M")
+	if work.Collapsed || !plans.Collapsed || !home.Collapsed {
+		t.Fatal("Expected zM to fold below the zoomed bullet only")
+	}
+}
+
+func TestJumpList(t *testing.T) {
+	work := NewBullet("Work")
+	plans := NewBullet("Plans")
+	work.AddChild(plans)
+	q3 := NewBullet("Q3")
+	plans.AddChild(q3)
+	home := NewBullet("Home")
+	m := newTestModel(home, work)
+
+	// Zooming out reselects the bullet we came from
+	m = pressKeys(m, "down", "right", "down", "right", "left")
+	if m.zoomedBullet != work || m.getSelectedBullet() != plans {
+		t.Fatal("Expected zooming out of Plans to select Plans inside Work")
+	}
+	m = pressKeys(m, "left")
+	if m.zoomedBullet != nil || m.getSelectedBullet() != work {
+		t.Fatal("Expected zooming out of Work to select Work")
+	}
+
+	// Back and forward walk the recorded locations
+	m = pressKeys(m, "ctrl+o")
+	if m.zoomedBullet != work {
+		t.Fatal("Expected ctrl+o to go back into Work")
+	}
+	m = pressKeys(m, "alt+left")
+	if m.zoomedBullet != plans || m.getSelectedBullet() != plans {
+		t.Fatal("Expected alt+left to go back into Plans")
+	}
+	m = pressKeys(m, "alt+right", "alt+right")
+	if m.zoomedBullet != nil || m.getSelectedBullet() != work {
+		t.Fatal("Expected alt+right to return to the top level")
+	}
+	m = pressKeys(m, "alt+right")
+	if m.statusMessage != "No newer location" {
+		t.Errorf("Expected the end of the jump list, got %q", m.statusMessage)
+	}
+
+	// Jumping somewhere new drops the forward history
+	m = pressKeys(m, "ctrl+o", "ctrl+p", "q3", "enter")
+	if len(m.jumpForward) != 0 || m.zoomedBullet != plans {
+		t.Error("Expected a new jump to clear the forward list")
+	}
+}
+
+func TestMotions(t *testing.T) {
+	work := NewBullet("Work")
+	for _, name := range []string{"A", "B", "C", "D"} {
+		work.AddChild(NewBullet(name))
+	}
+	home := NewBullet("Home")
+	m := newTestModel(work, home)
+
+	m = pressKeys(m, "G")
+	if m.getSelectedBullet() != home {
+		t.Fatal("Expected G to go to the last bullet")
+	}
+	m = pressKeys(m, "3", "G")
+	if m.getSelectedBullet() != work.Children[1] {
+		t.Fatal("Expected 3G to go to the third bullet")
+	}
+	m = pressKeys(m, "g", "2", "j")
+	if m.getSelectedBullet() != work.Children[1] {
+		t.Fatal("Expected 2j to move down two bullets")
+	}
+	m = pressKeys(m, "]", "]")
+	if m.getSelectedBullet() != work.Children[3] {
+		t.Fatal("Expected ] to go to the next sibling")
+	}
+	m = pressKeys(m, "{", ")")
+	if m.getSelectedBullet() != work.Children[3] {
+		t.Fatal("Expected { then ) to go to the parent and back to its last child")
+	}
+	m = pressKeys(m, "{", "(", "]", "[")
+	if m.getSelectedBullet() != work.Children[0] {
+		t.Fatal("Expected (, ] and [ to go to the first child and back")
+	}
+
+	// A count repeats structural commands, undone in one step, even when
+	// the history is full
+	for len(m.undoStack) < maxUndoHistory {
+		m.undoStack = append(m.undoStack, m.snapshot())
+	}
+	m = pressKeys(m, "G", "k", "3", "shift+up")
+	if work.Children[0].Content != "D" {
+		t.Fatal("Expected 3 shift+up to move D to the top")
+	}
+	m = pressKeys(m, "u")
+	work = m.rootBullets[0]
+	if work.Children[3].Content != "D" || len(m.undoStack) != maxUndoHistory-1 {
+		t.Fatalf("Expected one undo to revert all three moves, %d entries left", len(m.undoStack))
+	}
+	m = pressKeys(m, "2", ">")
+	if len(work.Children) != 3 || len(work.Children[2].Children) != 1 {
+		t.Error("Expected 2> to indent D under C")
+	}
+}
+
+func TestStickyAncestorsLeaveRoomForTheList(t *testing.T) {
+	root := NewBullet("Level 0")
+	deepest := root
+	for i := 1; i < 20; i++ {
+		child := NewBullet(fmt.Sprintf("Level %d", i))
+		deepest.AddChild(child)
+		deepest = child
+	}
+	m := newTestModel(root)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 12})
+	m = updated.(Model)
+
+	m = pressKeys(m, "G")
+	sticky := m.stickyAncestors(m.scrollOffset)
+	if len(sticky) != m.contentHeight()-1 || sticky[len(sticky)-1] != deepest.Parent {
+		t.Fatalf("Expected the innermost %d ancestors to be pinned, got %d", m.contentHeight()-1, len(sticky))
+	}
+	if m.getSelectedBullet() != deepest || m.selectedIndex >= m.visibleEnd() {
+		t.Error("Expected the deepest bullet to stay visible")
+	}
+}
+
+func click(m Model, x, y int) Model {
+	updated, _ := m.Update(tea.MouseMsg{X: x, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	return updated.(Model)
+}
+
+func TestMouse(t *testing.T) {
+	work := NewBullet("Work")
+	plans := NewBullet("Plans")
+	work.AddChild(plans)
+	task := NewBullet("Ship it")
+	task.ToggleTask()
+	plans.AddChild(task)
+	m := newTestModel(work, NewBullet("Other"))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 60, Height: 20})
+	m = updated.(Model)
+
+	// Rows start below the title; nested bullets are indented by four
+	m = click(m, 10, 5)
+	if m.getSelectedBullet() != plans {
+		t.Fatal("Expected a click to select Plans")
+	}
+	m = click(m, 20, 6)
+	m = click(m, 20, 6)
+	if m.editMode != EditModeEdit || m.editingBullet != task {
+		t.Fatal("Expected a double-click to edit the bullet")
+	}
+	m = pressKeys(m, "esc")
+
+	m = click(m, 10, 6)
+	if !task.Completed {
+		t.Fatal("Expected a click on the checkbox to complete the task")
+	}
+	m = click(m, 6, 5)
+	if !plans.Collapsed || len(m.allBullets) != 3 {
+		t.Fatal("Expected a click on the caret to collapse Plans")
+	}
+
+	// Breadcrumbs zoom out to the clicked level
+	m = pressKeys(m, "right")
+	m = click(m, 3, breadcrumbRow)
+	if m.zoomedBullet != work || m.getSelectedBullet() != plans {
+		t.Fatal("Expected a click on Work in the breadcrumbs to zoom out to it")
+	}
+
+	// The wheel scrolls the viewport and drags the selection along
+	for i := 0; i < 30; i++ {
+		work.AddChild(NewBullet("Filler"))
+	}
+	m.rebuildVisibleList()
+	updated, _ = m.Update(tea.MouseMsg{Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+	m = updated.(Model)
+	if m.scrollOffset != wheelLines || m.selectedIndex < m.scrollOffset {
+		t.Errorf("Expected the wheel to scroll by %d, got offset %d and selection %d", wheelLines, m.scrollOffset, m.selectedIndex)
+	}
+}
+
+func TestStickyAncestors(t *testing.T) {
+	work := NewBullet("Work")
+	plans := NewBullet("Plans")
+	work.AddChild(plans)
+	for i := 0; i < 30; i++ {
+		plans.AddChild(NewBullet("Item"))
+	}
+	m := newTestModel(work, NewBullet("Other"))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 60, Height: 20})
+	m = updated.(Model)
+
+	if len(m.stickyAncestors(m.scrollOffset)) != 0 {
+		t.Fatal("Expected no pinned ancestors at the top")
+	}
+
+	// Deep in the branch, its ancestors are pinned above the list
+	m = pressKeys(m, "G", "k")
+	sticky := m.stickyAncestors(m.scrollOffset)
+	if len(sticky) != 2 || sticky[0] != work || sticky[1] != plans {
+		t.Fatalf("Expected Work and Plans to be pinned, got %d ancestors", len(sticky))
+	}
+
+	// The pinned lines don't push the selection off screen
+	view := m.View()
+	if !strings.Contains(view, "Plans") || m.selectedIndex >= m.visibleEnd() {
+		t.Error("Expected the selection to stay visible below the pinned ancestors")
+	}
+
+	// Clicking a pinned ancestor selects it
+	m = click(m, 20, m.listTop())
+	if m.getSelectedBullet() != work {
+		t.Error("Expected a click on the pinned Work to select it")
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// doubleClickTime is the longest gap between the clicks of a double-click.
+	doubleClickTime = 400 * time.Millisecond
+	// wheelLines is how far one wheel step scrolls.
+	wheelLines = 3
+	// Screen layout of the outline view: one line of padding above the title
+	// and two to the left of everything.
+	padLeft       = 2
+	breadcrumbRow = 3
+)
+
+// listTop is the screen row of the first bullet line: below the title, and
+// below the breadcrumbs when zoomed.
+func (m Model) listTop() int {
+	if m.zoomedBullet != nil {
+		return 5
+	}
+	return 4
+}
+
+// bulletAt finds the bullet drawn on screen row y, and which of its lines
+// was hit. The pinned ancestors count as the first line of their bullet.
+func (m Model) bulletAt(y int) (index, line int, ok bool) {
+	row := y - m.listTop()
+	if row < 0 || row >= m.contentHeight() {
+		return 0, 0, false
+	}
+	sticky := m.stickyAncestors(m.scrollOffset)
+	if row < len(sticky) {
+		return indexOf(m.allBullets, sticky[row]), 0, true
+	}
+	row -= len(sticky)
+	for i := m.scrollOffset; i < len(m.allBullets); i++ {
+		lines := len(m.renderBullet(i))
+		if row < lines {
+			return i, row, true
+		}
+		row -= lines
+	}
+	return 0, 0, false
+}
+
+// visibleEnd is the index after the last bullet that starts on screen.
+func (m Model) visibleEnd() int {
+	used := 0
+	end := m.scrollOffset
+	for i := m.scrollOffset; i < len(m.allBullets) && used < m.listHeight(); i++ {
+		used += len(m.renderBullet(i))
+		end = i + 1
+	}
+	return end
+}
+
+// handleMouse selects, toggles and edits bullets by clicking, scrolls with
+// the wheel and zooms out by clicking a breadcrumb.
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.appMode != AppModeNormal || m.editMode != EditModeNone {
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.scroll(-wheelLines)
+		return m, nil
+	case tea.MouseButtonWheelDown:
+		m.scroll(wheelLines)
+		return m, nil
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+	default:
+		return m, nil
+	}
+
+	m.statusMessage = ""
+	m.stopVisual()
+	m.pendingKey = ""
+	m.count = 0
+
+	if m.zoomedBullet != nil && msg.Y == breadcrumbRow {
+		m.clickBreadcrumb(msg.X - padLeft)
+		return m, nil
+	}
+
+	index, line, ok := m.bulletAt(msg.Y)
+	if !ok {
+		return m, nil
+	}
+	b := m.allBullets[index]
+	doubleClick := b.ID == m.lastClickID && time.Since(m.lastClick) < doubleClickTime
+	m.lastClick, m.lastClickID = time.Now(), b.ID
+	m.selectedIndex = index
+	m.ensureSelectedVisible()
+
+	if line == 0 {
+		// Clicks on the caret or checkbox toggle them
+		column := msg.X - padLeft - 4*m.bulletDepth(b)
+		hasCaret := len(b.Children) > 0
+		switch {
+		case column < 0:
+		case hasCaret && column < 2:
+			b.Toggle()
+			m.rebuildVisibleList()
+			m.selectBullet(b)
+			return m, nil
+		case b.IsTask && column < lipgloss.Width(bulletPrefix(b)):
+			if m.readOnly {
+				m.statusMessage = "Read-only: changes are disabled"
+				return m, nil
+			}
+			m.recordUndo()
+			b.ToggleComplete()
+			// Auto-save after completing by mouse
+			m.saveData()
+			return m, nil
+		}
+	}
+
+	if doubleClick {
+		if m.readOnly {
+			m.statusMessage = "Read-only: changes are disabled"
+			return m, nil
+		}
+		m.lastClickID = ""
+		return m, m.startEdit(b, len([]rune(b.Content)))
+	}
+	return m, nil
+}
+
+// scroll moves the viewport by lines bullets, dragging the selection along
+// when it would leave the screen.
+func (m *Model) scroll(lines int) {
+	m.scrollOffset += lines
+	if m.scrollOffset > len(m.allBullets)-1 {
+		m.scrollOffset = len(m.allBullets) - 1
+	}
+	if m.scrollOffset < 0 {
+		m.scrollOffset = 0
+	}
+	if m.selectedIndex < m.scrollOffset {
+		m.selectedIndex = m.scrollOffset
+	}
+	if end := m.visibleEnd(); m.selectedIndex >= end {
+		m.selectedIndex = end - 1
+	}
+	// Don't scroll past the end of the outline
+	m.ensureSelectedVisible()
+}
+
+// clickBreadcrumb zooms out to the breadcrumb at column x.
+func (m *Model) clickBreadcrumb(x int) {
+	start := 0
+	for _, crumb := range m.breadcrumbs {
+		end := start + lipgloss.Width(crumb.Content)
+		if x >= start && x < end {
+			m.pushJump()
+			from := m.zoomedBullet
+			m.zoomTo(crumb)
+			// Select the branch we came from
+			for b := from; b != nil; b = b.Parent {
+				if b.Parent == crumb {
+					m.selectBullet(b)
+				}
+			}
+			return
+		}
+		start = end + len(" > ")
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const noteEditorHeight = 5
+
+func newNoteInput() textarea.Model {
+	ta := textarea.New()
+	ta.Placeholder = "Write a note..."
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	ta.SetHeight(noteEditorHeight)
+	return ta
+}
+
+// startNoteEdit opens the multi-line note editor under the bullet.
+func (m *Model) startNoteEdit(b *Bullet) tea.Cmd {
+	m.editMode = EditModeNote
+	m.editingBullet = b
+	b.IsEditing = true
+
+	width := m.width - 4*(b.GetDepth()+2)
+	if width < 20 {
+		width = 20
+	}
+	m.noteInput.SetWidth(width)
+	m.noteInput.SetValue(b.Note)
+	m.noteInput.Focus()
+	m.ensureSelectedVisible()
+	return textarea.Blink
+}
+
+// updateNoteEditor handles keys while a note is being edited. Enter inserts
+// a new line, so the note is committed with ctrl+s instead.
+func (m Model) updateNoteEditor(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+s":
+		note := strings.TrimRight(m.noteInput.Value(), " \n")
+		if m.editingBullet != nil && note != m.editingBullet.Note {
+			m.recordUndo()
+			m.editingBullet.Note = note
+			// Auto-save after editing a note
+			m.saveData()
+		}
+		m.closeNoteEditor()
+		return m, nil
+
+	case "esc":
+		m.closeNoteEditor()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.noteInput, cmd = m.noteInput.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) closeNoteEditor() {
+	m.editMode = EditModeNone
+	if m.editingBullet != nil {
+		m.editingBullet.IsEditing = false
+		m.editingBullet = nil
+	}
+	m.noteInput.SetValue("")
+	m.noteInput.Blur()
+	m.ensureSelectedVisible()
+}
+
+// noteShown reports whether the bullet's note is displayed under it: always
+// for the selected bullet, and for every bullet if enabled in settings.
+func (m Model) noteShown(b *Bullet) bool {
+	if b.Note == "" {
+		return false
+	}
+	return m.settings.ShowAllNotes || b == m.getSelectedBullet()
+}
+
+// noteLines renders the note (or the note editor) shown below a bullet.
+func (m Model) noteLines(b *Bullet) []string {
+	if m.editMode == EditModeNote && b == m.editingBullet {
+		return strings.Split(m.noteInput.View(), "\n")
+	}
+	if !m.noteShown(b) {
+		return nil
+	}
+
+	noteStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("245")).
+		Faint(true)
+
+	// Notes are searched as a whole, so match positions span their lines
+	matches := m.searchRanges(b.Note)
+	var lines []string
+	start := 0
+	for _, line := range strings.Split(b.Note, "\n") {
+		lines = append(lines, m.highlightSpan(b.Note, start, start+len(line), matches, noteStyle))
+		start += len(line) + 1
+	}
+	return lines
+}
+
+// continuationIndent lines up text below a bullet with the bullet's content,
+// continuing the hierarchy lines of the levels above it.
+func (m Model) continuationIndent(depth, prefixWidth int) string {
+	lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	var indent strings.Builder
+	for level := 0; level < depth; level++ {
+		if m.settings.ShowHierarchyLines {
+			indent.WriteString(lineStyle.Render("│   "))
+		} else {
+			indent.WriteString("    ")
+		}
+	}
+	indent.WriteString(strings.Repeat(" ", prefixWidth))
+	return indent.String()
+}
@@ -160,4 +160,168 @@ func TestDataPreservationOnUpdate(t *testing.T) {
 			t.Error("Tutorial data should not appear for existing users")
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestUndoHistoryPersistence(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "ocli_history_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cm := &ConfigManager{
+		configDir:   tempDir,
+		configFile:  filepath.Join(tempDir, "data.json"),
+		historyFile: filepath.Join(tempDir, "history.json"),
+	}
+
+	m := newTestModel(NewBullet("First"))
+	m.configManager = cm
+	m = pressKeys(m, "enter", "Second", "enter", "esc")
+	if err := m.saveData(); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+
+	// A new session on the same outline can undo the previous session's change
+	data, err := cm.Load()
+	if err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+	history, err := cm.LoadHistory()
+	if err != nil {
+		t.Fatalf("Failed to load history: %v", err)
+	}
+	restored := newTestModel(data.RootBullets...)
+	restored.restoreHistory(history)
+	restored.undo()
+	if len(restored.rootBullets) != 1 || restored.rootBullets[0].Content != "First" {
+		t.Errorf("Expected undo of the persisted change, got %d bullets", len(restored.rootBullets))
+	}
+
+	// History saved for a different outline is ignored
+	changed := newTestModel(NewBullet("Edited elsewhere"))
+	changed.restoreHistory(history)
+	if len(changed.undoStack) != 0 {
+		t.Error("Expected history for a different outline to be discarded")
+	}
+}
+
+func TestTrashPersistence(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "ocli_trash_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cm := &ConfigManager{
+		configDir:  tempDir,
+		configFile: filepath.Join(tempDir, "data.json"),
+	}
+
+	// A deleted branch with children must save without parent cycles
+	parent := NewBullet("Parent")
+	parent.AddChild(NewBullet("Child"))
+	m := newTestModel(parent)
+	m.configManager = cm
+	m = pressKeys(m, "d")
+	if err := m.saveData(); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+
+	data, err := cm.Load()
+	if err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+	if len(data.RootBullets) != 0 || len(data.Trash) != 1 {
+		t.Fatalf("Expected an empty outline and one trash entry, got %d and %d", len(data.RootBullets), len(data.Trash))
+	}
+	if children := data.Trash[0].Bullet.Children; len(children) != 1 || children[0].Content != "Child" {
+		t.Error("Expected the trashed branch to keep its children")
+	}
+}
+
+func TestBookmarksPersistence(t *testing.T) {
+	dir := t.TempDir()
+	cm := &ConfigManager{configDir: dir, configFile: filepath.Join(dir, "data.json")}
+
+	work := NewBullet("Work")
+	plans := NewBullet("Plans")
+	work.AddChild(plans)
+	inbox := NewBullet("Inbox")
+	m := newTestModel(work, inbox)
+	m.configManager = cm
+
+	// Stars follow the bullet when it moves
+	m = pressKeys(m, "down", "*", "m", "inbox", "enter")
+	if err := m.saveData(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := cm.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Bookmarks) != 1 || data.Bookmarks[0] != plans.ID {
+		t.Fatalf("Expected the star on Plans to be saved, got %v", data.Bookmarks)
+	}
+
+	loaded := newTestModel(data.RootBullets...)
+	loaded.bookmarks = data.Bookmarks
+	loaded = pressKeys(loaded, "b")
+	if bullets := loaded.bookmarkedBullets(); len(bullets) != 1 || bullets[0].Parent.Content != "Inbox" {
+		t.Fatal("Expected the bookmark to resolve to Plans under Inbox")
+	}
+	loaded = pressKeys(loaded, "1")
+	if loaded.appMode != AppModeNormal || loaded.getSelectedBullet().Content != "Plans" {
+		t.Fatal("Expected 1 to jump to the first bookmark")
+	}
+
+	// Unstarring can be undone
+	loaded = pressKeys(loaded, "*", "u")
+	if len(loaded.bookmarks) != 1 {
+		t.Error("Expected undo to bring the star back")
+	}
+}
+
+func TestViewStatePersistence(t *testing.T) {
+	dir := t.TempDir()
+	cm := &ConfigManager{configDir: dir, configFile: filepath.Join(dir, "data.json")}
+
+	work := NewBullet("Work")
+	plans := NewBullet("Plans")
+	work.AddChild(plans)
+	plans.AddChild(NewBullet("Q3"))
+	launch := NewBullet("Launch")
+	plans.AddChild(launch)
+	m := newTestModel(work, NewBullet("Inbox"))
+	m.configManager = cm
+
+	m = pressKeys(m, "down", "right", "down", "down", "q")
+
+	data, err := cm.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.View == nil || data.View.ZoomedID != plans.ID || data.View.SelectedID != launch.ID {
+		t.Fatalf("Expected the zoom and selection to be saved by ID, got %+v", data.View)
+	}
+
+	restored := newTestModel(data.RootBullets...)
+	restored.restoreView(data.View)
+	if restored.zoomedBullet == nil || restored.zoomedBullet.ID != plans.ID {
+		t.Fatal("Expected the zoom to be restored")
+	}
+	if len(restored.breadcrumbs) != 1 || restored.breadcrumbs[0].ID != work.ID {
+		t.Error("Expected the breadcrumbs to be rebuilt")
+	}
+	if selected := restored.getSelectedBullet(); selected == nil || selected.ID != launch.ID {
+		t.Error("Expected the selection to be restored")
+	}
+
+	// A view pointing at bullets that are gone falls back to the top
+	fresh := newTestModel(NewBullet("Other"))
+	fresh.restoreView(data.View)
+	if fresh.zoomedBullet != nil || fresh.selectedIndex != 0 {
+		t.Error("Expected a stale view to be ignored")
+	}
+}
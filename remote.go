@@ -0,0 +1,240 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RemoteCommand is a single JSON request sent over the control socket.
+type RemoteCommand struct {
+	Action  string `json:"action"`
+	ID      string `json:"id,omitempty"`
+	Parent  string `json:"parent,omitempty"`
+	Content string `json:"content,omitempty"`
+	Query   string `json:"query,omitempty"`
+}
+
+// RemoteResponse is the reply written back for every RemoteCommand.
+type RemoteResponse struct {
+	OK      bool          `json:"ok"`
+	Error   string        `json:"error,omitempty"`
+	ID      string        `json:"id,omitempty"`
+	Matches []RemoteMatch `json:"matches,omitempty"`
+}
+
+// RemoteMatch describes one bullet returned by a search command.
+type RemoteMatch struct {
+	ID   string `json:"id"`
+	Path string `json:"path"`
+}
+
+// remoteCommandMsg carries a command from the socket into Model.Update so it
+// is applied on the same goroutine as keyboard input.
+type remoteCommandMsg struct {
+	command RemoteCommand
+	reply   chan RemoteResponse
+}
+
+// remoteUnavailableMsg reports that the control socket could not be started,
+// so `ocli ctl` will not reach this instance.
+type remoteUnavailableMsg struct {
+	err error
+}
+
+const remoteReplyTimeout = 5 * time.Second
+
+// RemoteServer listens on a Unix socket and forwards commands to the running TUI.
+type RemoteServer struct {
+	listener net.Listener
+	path     string
+}
+
+// StartRemoteServer starts listening on path. Each command is delivered via
+// send (normally tea.Program.Send) and answered once the model has handled it.
+func StartRemoteServer(path string, send func(tea.Msg)) (*RemoteServer, error) {
+	if _, err := os.Stat(path); err == nil {
+		// A live socket means another OCLI is already listening; a dead one
+		// is left over from a crash and can be replaced.
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("another OCLI instance is already listening on %s", path)
+		}
+		os.Remove(path)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on control socket: %w", err)
+	}
+	os.Chmod(path, 0600)
+
+	s := &RemoteServer{listener: listener, path: path}
+	go s.acceptLoop(send)
+	return s, nil
+}
+
+func (s *RemoteServer) acceptLoop(send func(tea.Msg)) {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return // Listener closed
+		}
+		go s.handleConn(conn, send)
+	}
+}
+
+func (s *RemoteServer) handleConn(conn net.Conn, send func(tea.Msg)) {
+	defer conn.Close()
+
+	decoder := json.NewDecoder(conn)
+	encoder := json.NewEncoder(conn)
+	for {
+		var command RemoteCommand
+		if err := decoder.Decode(&command); err != nil {
+			return
+		}
+
+		reply := make(chan RemoteResponse, 1)
+		send(remoteCommandMsg{command: command, reply: reply})
+
+		var response RemoteResponse
+		select {
+		case response = <-reply:
+		case <-time.After(remoteReplyTimeout):
+			response = RemoteResponse{Error: "timed out waiting for OCLI"}
+		}
+		if err := encoder.Encode(response); err != nil {
+			return
+		}
+	}
+}
+
+// Close stops accepting commands and removes the socket file.
+func (s *RemoteServer) Close() error {
+	err := s.listener.Close()
+	os.Remove(s.path)
+	return err
+}
+
+// SendRemoteCommand delivers a single command to the OCLI listening on path.
+func SendRemoteCommand(path string, command RemoteCommand) (RemoteResponse, error) {
+	var response RemoteResponse
+
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err != nil {
+		return response, fmt.Errorf("OCLI is not running (no control socket at %s)", path)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(remoteReplyTimeout + time.Second))
+
+	if err := json.NewEncoder(conn).Encode(command); err != nil {
+		return response, fmt.Errorf("failed to send command: %w", err)
+	}
+	if err := json.NewDecoder(conn).Decode(&response); err != nil {
+		return response, fmt.Errorf("failed to read response: %w", err)
+	}
+	return response, nil
+}
+
+// remoteBlocker describes the open editor, prompt or panel that holds on to
+// bullets of the outline, or returns "" when none is open. Commands that
+// change the outline or the view are refused meanwhile.
+func (m Model) remoteBlocker() string {
+	switch {
+	case m.editMode == EditModeNew, m.editMode == EditModeEdit:
+		return "a bullet is being edited"
+	case m.editMode == EditModeNote:
+		return "a note is being edited"
+	case m.editMode == EditModeSearch, m.editMode == EditModeFilter:
+		return "a search or filter prompt is open"
+	case m.appMode == AppModePicker:
+		return "a picker is open"
+	case m.appMode == AppModeTrash:
+		return "the trash view is open"
+	case m.appMode == AppModeBookmarks:
+		return "the bookmarks panel is open"
+	}
+	return ""
+}
+
+// handleRemoteCommand applies a command received over the control socket.
+func (m *Model) handleRemoteCommand(command RemoteCommand) RemoteResponse {
+	selected := m.getSelectedBullet()
+
+	if command.Action != "search" {
+		if blocker := m.remoteBlocker(); blocker != "" {
+			return RemoteResponse{Error: fmt.Sprintf("OCLI is busy: %s, try again once it is closed", blocker)}
+		}
+	}
+
+	switch command.Action {
+	case "add":
+		if command.Content == "" {
+			return RemoteResponse{Error: "add requires content"}
+		}
+		newBullet := NewBullet(command.Content)
+		if command.Parent == "" {
+			m.recordUndo()
+			m.rootBullets = append(m.rootBullets, newBullet)
+		} else {
+			parent, err := resolveBulletID(m.rootBullets, command.Parent)
+			if err != nil {
+				return RemoteResponse{Error: err.Error()}
+			}
+			m.recordUndo()
+			parent.AddChild(newBullet)
+		}
+		m.rebuildVisibleList()
+		m.selectBullet(selected)
+		m.saveData()
+		return RemoteResponse{OK: true, ID: newBullet.ID}
+
+	case "complete":
+		target, err := resolveBulletID(m.rootBullets, command.ID)
+		if err != nil {
+			return RemoteResponse{Error: err.Error()}
+		}
+		if !target.IsTask {
+			return RemoteResponse{Error: fmt.Sprintf("%q is not a task", target.Content)}
+		}
+		m.recordUndo()
+		target.Completed = true
+		m.saveData()
+		return RemoteResponse{OK: true, ID: target.ID}
+
+	case "zoom":
+		if command.ID == "" {
+			m.zoomTo(nil)
+			return RemoteResponse{OK: true}
+		}
+		target, err := resolveBulletID(m.rootBullets, command.ID)
+		if err != nil {
+			return RemoteResponse{Error: err.Error()}
+		}
+		m.zoomTo(target)
+		return RemoteResponse{OK: true, ID: target.ID}
+
+	case "search":
+		if command.Query == "" {
+			return RemoteResponse{Error: "search requires a query"}
+		}
+		query := strings.ToLower(command.Query)
+		response := RemoteResponse{OK: true}
+		walkBullets(m.rootBullets, func(b *Bullet) {
+			if strings.Contains(strings.ToLower(b.Content), query) {
+				response.Matches = append(response.Matches, RemoteMatch{ID: b.ID, Path: b.PathString()})
+			}
+		})
+		// Archived branches stay searchable
+		response.Matches = append(response.Matches, m.removedMatches(query)...)
+		return response
+	}
+
+	return RemoteResponse{Error: fmt.Sprintf("unknown action %q", command.Action)}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRemoteCommands(t *testing.T) {
+	project := NewBullet("Project")
+	task := NewBullet("Ship release")
+	task.ToggleTask()
+	project.AddChild(task)
+	m := newTestModel(project)
+
+	response := m.handleRemoteCommand(RemoteCommand{Action: "add", Parent: project.ID[:8], Content: "Write notes"})
+	if !response.OK {
+		t.Fatalf("add failed: %s", response.Error)
+	}
+	if len(project.Children) != 2 || project.Children[1].Content != "Write notes" {
+		t.Fatalf("Expected new child under project, got %d children", len(project.Children))
+	}
+
+	response = m.handleRemoteCommand(RemoteCommand{Action: "complete", ID: task.ID})
+	if !response.OK || !task.Completed {
+		t.Errorf("Expected task to be completed, got %+v", response)
+	}
+
+	response = m.handleRemoteCommand(RemoteCommand{Action: "complete", ID: project.ID})
+	if response.OK {
+		t.Error("Completing a non-task should fail")
+	}
+
+	response = m.handleRemoteCommand(RemoteCommand{Action: "zoom", ID: project.ID})
+	if !response.OK || m.zoomedBullet != project {
+		t.Errorf("Expected zoom into project, got %+v", response)
+	}
+
+	response = m.handleRemoteCommand(RemoteCommand{Action: "search", Query: "NOTES"})
+	if len(response.Matches) != 1 || response.Matches[0].Path != "Project > Write notes" {
+		t.Errorf("Unexpected search result: %+v", response.Matches)
+	}
+
+	// Changes wait until the open editor is closed; search still works
+	m = pressKeys(m, "e")
+	response = m.handleRemoteCommand(RemoteCommand{Action: "add", Content: "Later"})
+	if response.OK || len(m.rootBullets) != 1 {
+		t.Errorf("Expected add to be refused while editing, got %+v", response)
+	}
+	response = m.handleRemoteCommand(RemoteCommand{Action: "search", Query: "notes"})
+	if !response.OK {
+		t.Errorf("Expected search to work while editing, got %+v", response)
+	}
+}
+
+func TestRemoteServerRoundTrip(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "ocli_remote_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	m := newTestModel(NewBullet("Inbox"))
+	socketPath := filepath.Join(tempDir, "ocli.sock")
+
+	// Stand in for tea.Program.Send by handling messages on one goroutine
+	msgs := make(chan tea.Msg)
+	go func() {
+		for msg := range msgs {
+			updated, _ := m.Update(msg)
+			m = updated.(Model)
+		}
+	}()
+	defer close(msgs)
+
+	server, err := StartRemoteServer(socketPath, func(msg tea.Msg) { msgs <- msg })
+	if err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	defer server.Close()
+
+	if _, err := StartRemoteServer(socketPath, func(tea.Msg) {}); err == nil {
+		t.Error("Expected second server on the same socket to fail")
+	}
+
+	response, err := SendRemoteCommand(socketPath, RemoteCommand{Action: "search", Query: "inbox"})
+	if err != nil {
+		t.Fatalf("Failed to send command: %v", err)
+	}
+	if !response.OK || len(response.Matches) != 1 {
+		t.Errorf("Unexpected response: %+v", response)
+	}
+
+	response, err = SendRemoteCommand(socketPath, RemoteCommand{Action: "bogus"})
+	if err != nil {
+		t.Fatalf("Failed to send command: %v", err)
+	}
+	if response.OK || response.Error == "" {
+		t.Errorf("Expected error for unknown action, got %+v", response)
+	}
+}
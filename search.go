@@ -0,0 +1,303 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// searchState is the state of `/` search. It stays around after the prompt
+// is closed so n/N can keep jumping and matches stay highlighted until Esc.
+type searchState struct {
+	input         textinput.Model
+	caseSensitive bool
+	regex         bool
+	pattern       *regexp.Regexp // nil while the query is empty or invalid
+	err           error
+	origin        *Bullet // Selected when the prompt was opened
+}
+
+// openSearch shows the search prompt in place of the help line. The case and
+// regex options carry over from the previous search.
+func (m *Model) openSearch() tea.Cmd {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = "search"
+	input.Focus()
+
+	s := &searchState{input: input, origin: m.getSelectedBullet()}
+	if m.search != nil {
+		s.caseSensitive = m.search.caseSensitive
+		s.regex = m.search.regex
+	}
+	m.search = s
+	m.editMode = EditModeSearch
+	return textinput.Blink
+}
+
+// compile rebuilds the pattern from the query and options.
+func (s *searchState) compile() {
+	s.pattern, s.err = nil, nil
+	query := s.input.Value()
+	if query == "" {
+		return
+	}
+	if !s.regex {
+		query = regexp.QuoteMeta(query)
+	}
+	if !s.caseSensitive {
+		query = "(?i)" + query
+	}
+	s.pattern, s.err = regexp.Compile(query)
+}
+
+// isMatch reports whether b's content or note matches the active search.
+func (m Model) isMatch(b *Bullet) bool {
+	if b == nil || m.search == nil || m.search.pattern == nil {
+		return false
+	}
+	return m.search.pattern.MatchString(b.Content) || m.search.pattern.MatchString(b.Note)
+}
+
+// searchMatches returns every matching bullet in outline order, including
+// those inside collapsed branches and outside the zoomed bullet.
+func (m Model) searchMatches() []*Bullet {
+	var matches []*Bullet
+	walkBullets(m.rootBullets, func(b *Bullet) {
+		if m.isMatch(b) {
+			matches = append(matches, b)
+		}
+	})
+	return matches
+}
+
+// archivedMatches counts the archived bullets that match the active search.
+// They cannot be jumped to, so the search line only points at the archive.
+func (m Model) archivedMatches() int {
+	count := 0
+	for _, entry := range m.archive {
+		walkBullets([]*Bullet{entry.Bullet}, func(b *Bullet) {
+			if m.isMatch(b) {
+				count++
+			}
+		})
+	}
+	return count
+}
+
+// jumpToMatch selects the next (or previous) match after the selected bullet
+// in outline order, wrapping around, and reveals it.
+func (m *Model) jumpToMatch(forward bool) {
+	matches := m.searchMatches()
+	if len(matches) == 0 {
+		m.statusMessage = "No matches"
+		return
+	}
+
+	order := make(map[*Bullet]int)
+	walkBullets(m.rootBullets, func(b *Bullet) {
+		order[b] = len(order)
+	})
+	current := -1
+	if selected := m.getSelectedBullet(); selected != nil {
+		current = order[selected]
+	}
+
+	target := matches[0]
+	if forward {
+		for _, b := range matches {
+			if order[b] > current {
+				target = b
+				break
+			}
+		}
+	} else {
+		target = matches[len(matches)-1]
+		for i := len(matches) - 1; i >= 0; i-- {
+			if order[matches[i]] < current {
+				target = matches[i]
+				break
+			}
+		}
+	}
+	m.reveal(target)
+}
+
+// reveal makes b visible, zooming out only as far as needed and expanding
+// its collapsed ancestors, then selects it.
+func (m *Model) reveal(b *Bullet) {
+	zoom := m.zoomedBullet
+	for zoom != nil && !isAncestorOrSelf(zoom, b) {
+		zoom = zoom.Parent
+	}
+	if zoom != m.zoomedBullet {
+		m.pushJump()
+		m.zoomTo(zoom)
+	}
+	if m.filterActive() {
+		// The filtered view ignores folding; show the match along with the others
+		m.keepInFilter(b)
+	} else {
+		for ancestor := b.Parent; ancestor != nil && ancestor != zoom; ancestor = ancestor.Parent {
+			ancestor.Collapsed = false
+		}
+	}
+	m.rebuildVisibleList()
+	m.selectBullet(b)
+}
+
+func isAncestorOrSelf(ancestor, b *Bullet) bool {
+	for current := b; current != nil; current = current.Parent {
+		if current == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// previewMatch selects the first visible match at or after where the search
+// started, without expanding or zooming, or goes back to the start.
+func (m *Model) previewMatch() {
+	s := m.search
+	start := indexOf(m.allBullets, s.origin)
+	if start < 0 {
+		start = 0
+	}
+	for n := 0; n < len(m.allBullets); n++ {
+		i := (start + n) % len(m.allBullets)
+		if m.isMatch(m.allBullets[i]) {
+			m.selectedIndex = i
+			m.ensureSelectedVisible()
+			return
+		}
+	}
+	m.selectBullet(s.origin)
+}
+
+// updateSearch handles keys while the search prompt is open. Typing only
+// moves to matches already on screen; Enter also reveals hidden ones.
+func (m Model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	s := m.search
+	var cmd tea.Cmd
+	switch msg.String() {
+	case "esc":
+		m.search = nil
+		m.editMode = EditModeNone
+		m.selectBullet(s.origin)
+		return m, nil
+
+	case "enter":
+		m.editMode = EditModeNone
+		s.input.Blur()
+		if s.pattern == nil {
+			m.search = nil
+			return m, nil
+		}
+		if !m.isMatch(m.getSelectedBullet()) {
+			m.jumpToMatch(true)
+		}
+		return m, nil
+
+	case "alt+c":
+		s.caseSensitive = !s.caseSensitive
+
+	case "alt+r":
+		s.regex = !s.regex
+
+	default:
+		s.input, cmd = s.input.Update(msg)
+	}
+	s.compile()
+	m.previewMatch()
+	return m, cmd
+}
+
+// highlightMatches renders text in style with the matches of the active
+// search marked.
+func (m Model) highlightMatches(text string, style lipgloss.Style) string {
+	return m.highlightSpan(text, 0, len(text), m.searchRanges(text), style)
+}
+
+// highlightWrapped soft-wraps text like wrapText and renders each line in
+// style. Matches are found before wrapping, so one that continues on the
+// next line is marked on both.
+func (m Model) highlightWrapped(text string, width int, style lipgloss.Style) []string {
+	matches := m.searchRanges(text)
+	var lines []string
+	for _, span := range wrapSpans(text, width) {
+		lines = append(lines, m.highlightSpan(text, span[0], span[1], matches, style))
+	}
+	return lines
+}
+
+// searchRanges returns the byte ranges of the active search's non-empty
+// matches in text.
+func (m Model) searchRanges(text string) [][]int {
+	if m.search == nil || m.search.pattern == nil {
+		return nil
+	}
+	var ranges [][]int
+	for _, match := range m.search.pattern.FindAllStringIndex(text, -1) {
+		if match[0] < match[1] {
+			ranges = append(ranges, match)
+		}
+	}
+	return ranges
+}
+
+// highlightSpan renders text[start:end] in style, marking the parts of it
+// covered by matches.
+func (m Model) highlightSpan(text string, start, end int, matches [][]int, style lipgloss.Style) string {
+	highlight := style.Copy().
+		Background(lipgloss.Color("220")).
+		Foreground(lipgloss.Color("0"))
+
+	var rendered string
+	last := start
+	for _, match := range matches {
+		from, to := max(match[0], start), min(match[1], end)
+		if from >= to {
+			continue
+		}
+		rendered += style.Render(text[last:from]) + highlight.Render(text[from:to])
+		last = to
+	}
+	if last == start {
+		return style.Render(text[start:end])
+	}
+	return rendered + style.Render(text[last:end])
+}
+
+// searchLine replaces the help line while a search is active.
+func (m Model) searchLine() string {
+	s := m.search
+	matches := m.searchMatches()
+	var archived string
+	if count := m.archivedMatches(); count > 0 {
+		archived = fmt.Sprintf(" (+%d archived, T then Tab)", count)
+	}
+	if m.editMode == EditModeSearch {
+		options := fmt.Sprintf("alt+c case: %s • alt+r regex: %s", onOff(s.caseSensitive), onOff(s.regex))
+		if s.err != nil {
+			return fmt.Sprintf("%s • invalid pattern • %s", s.input.View(), options)
+		}
+		return fmt.Sprintf("%s • %d matches%s • %s", s.input.View(), len(matches), archived, options)
+	}
+
+	count := fmt.Sprintf("%d matches", len(matches))
+	if i := indexOf(matches, m.getSelectedBullet()); i >= 0 {
+		count = fmt.Sprintf("match %d of %d", i+1, len(matches))
+	}
+	count += archived
+	return fmt.Sprintf("/%s • %s • n/N next/previous • esc clears", s.input.Value(), count)
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
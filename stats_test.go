@@ -0,0 +1,47 @@
+package main
+
+import "testing"
+
+func TestComputeStats(t *testing.T) {
+	work := NewBullet("Work")
+	launch := NewBullet("Launch")
+	work.AddChild(launch)
+	for i, done := range []bool{true, false, false} {
+		task := NewBullet("Task")
+		task.ToggleTask()
+		task.Completed = done
+		if i == 0 {
+			task.Color = ColorRed
+		}
+		launch.AddChild(task)
+	}
+	home := NewBullet("Home")
+	home.Color = ColorBlue
+
+	stats := computeStats([]*Bullet{work, home}, nil)
+
+	if stats.TotalBullets != 6 {
+		t.Errorf("Expected 6 bullets, got %d", stats.TotalBullets)
+	}
+	if len(stats.DepthDistribution) != 3 || stats.DepthDistribution[0] != 2 || stats.DepthDistribution[2] != 3 {
+		t.Errorf("Unexpected depth distribution: %v", stats.DepthDistribution)
+	}
+	if stats.OpenTasks != 2 || stats.DoneTasks != 1 {
+		t.Errorf("Expected 2 open / 1 done, got %d / %d", stats.OpenTasks, stats.DoneTasks)
+	}
+	if stats.Colors["default"] != 4 || stats.Colors["red"] != 1 || stats.Colors["blue"] != 1 {
+		t.Errorf("Unexpected color usage: %v", stats.Colors)
+	}
+	if len(stats.Branches) != 2 || stats.Branches[0].Bullets != 5 || stats.Branches[0].OpenTasks != 2 {
+		t.Errorf("Unexpected branch stats: %+v", stats.Branches)
+	}
+	if len(stats.LargestSubtrees) != 2 || stats.LargestSubtrees[0].Path != "Work" {
+		t.Errorf("Unexpected largest subtrees: %+v", stats.LargestSubtrees)
+	}
+
+	// Zoomed statistics are relative to the zoomed bullet
+	scoped := computeStats(work.Children, work)
+	if scoped.Scope != "Work" || scoped.TotalBullets != 4 || scoped.Branches[0].Path != "Launch" {
+		t.Errorf("Unexpected scoped stats: %+v", scoped)
+	}
+}